@@ -0,0 +1,218 @@
+// Package actions provides the actions run on session events, e.g. the notification
+// and commands after a task is completed, lifecycle hooks and webhooks.
+package actions
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Bahaaio/pomo/config"
+)
+
+// Action runs in the background on session events.
+type Action interface {
+	// Name identifies the action in logs and failures
+	Name() string
+
+	// Wants reports whether the action runs on the event, one of config.Events
+	Wants(event string) bool
+
+	// Run performs the action, it should stop when ctx is cancelled.
+	// a returned error is reported like a failed command
+	Run(ctx context.Context, event Event) error
+}
+
+// Event is a session event, e.g. complete, with the session it happened in.
+type Event struct {
+	Name    string
+	Task    config.Task // task of the session
+	Session Context
+
+	results chan<- CommandResult
+	banners chan<- Notice
+}
+
+// Report logs the result of a command or request and shows it in the timer if it failed,
+// failures are listed in the session summary.
+func (e Event) Report(result CommandResult) {
+	logResult(result)
+	report(e.results, result)
+}
+
+// Factory creates an action from an entry of the actions list.
+type Factory func(entry config.Action) (Action, error)
+
+var registry = map[string]Factory{}
+
+// Register makes an action type available to the actions list of the config,
+// it's meant to be called from init functions.
+func Register(actionType string, factory Factory) {
+	if _, exists := registry[actionType]; exists {
+		panic("actions: type " + actionType + " registered twice")
+	}
+
+	registry[actionType] = factory
+}
+
+func init() {
+	Register("command", newCommandAction)
+	Register("webhook", newWebhookAction)
+}
+
+// FromConfig returns the actions of the config: the task notification and then commands,
+// hooks, webhooks, and the actions list instantiated by their registered types.
+func FromConfig(cfg config.Config) ([]Action, error) {
+	actions := []Action{
+		notificationAction{notifications: cfg.Notifications},
+		thenAction{},
+	}
+
+	hooks := cfg.Hooks.ByName()
+	for _, name := range slices.Sorted(maps.Keys(hooks)) {
+		if len(hooks[name]) > 0 {
+			actions = append(actions, commandAction{name: name, events: []string{hookEvent(name)}, commands: hooks[name]})
+		}
+	}
+
+	for _, webhook := range cfg.Webhooks {
+		actions = append(actions, webhookAction{name: "webhook", webhook: webhook})
+	}
+
+	for i, entry := range cfg.Actions {
+		factory, ok := registry[entry.Type]
+		if !ok {
+			return nil, fmt.Errorf("unknown type '%v' of action %d, expected one of: %v",
+				entry.Type, i+1, strings.Join(slices.Sorted(maps.Keys(registry)), ", "))
+		}
+
+		action, err := factory(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid action %d: %w", i+1, err)
+		}
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
+
+// returns the event of a hook, e.g. onLongBreak -> longBreak
+func hookEvent(name string) string {
+	event := strings.TrimPrefix(name, "on")
+	if event == "" {
+		return event
+	}
+
+	return strings.ToLower(event[:1]) + event[1:]
+}
+
+// sends the notification of the completed task
+type notificationAction struct {
+	notifications config.Notifications
+}
+
+func (a notificationAction) Name() string { return "notification" }
+
+func (a notificationAction) Wants(event string) bool { return event == "complete" }
+
+func (a notificationAction) Run(ctx context.Context, event Event) error {
+	notifier := Notifier{Notifications: a.notifications, Banners: event.banners}
+	notifier.Send(ctx, event.Task.Notification, event.Session, event.results)
+
+	return nil
+}
+
+// runs the then commands of the completed task
+type thenAction struct{}
+
+func (a thenAction) Name() string { return "then" }
+
+func (a thenAction) Wants(event string) bool { return event == "complete" }
+
+func (a thenAction) Run(ctx context.Context, event Event) error {
+	runCommands(ctx, "then", event.Task.Then, event.Session, event.results)
+	return nil
+}
+
+// runs commands on its events, for hooks and command actions
+type commandAction struct {
+	name     string
+	events   []string
+	commands []config.Command
+}
+
+// creates a command action from its options, e.g. {command: [notify-send, hi], timeout: 10s}
+func newCommandAction(entry config.Action) (Action, error) {
+	var cmd config.Command
+	if err := entry.DecodeOptions(&cmd); err != nil {
+		return nil, err
+	}
+
+	if len(cmd.Args) == 0 {
+		return nil, fmt.Errorf("%v action has no command", entry.DisplayName())
+	}
+
+	return commandAction{
+		name:     entry.DisplayName(),
+		events:   entry.Events,
+		commands: []config.Command{config.ExpandCommand(cmd)},
+	}, nil
+}
+
+func (a commandAction) Name() string { return a.name }
+
+func (a commandAction) Wants(event string) bool { return config.MatchEvent(a.events, event) }
+
+func (a commandAction) Run(ctx context.Context, event Event) error {
+	runCommands(ctx, a.name, a.commands, event.Session, event.results)
+	return nil
+}
+
+// POSTs the event to a webhook
+type webhookAction struct {
+	name    string
+	webhook config.Webhook
+}
+
+// creates a webhook action from its options, e.g. {url: https://example.com, retries: 3}
+func newWebhookAction(entry config.Action) (Action, error) {
+	var webhook config.Webhook
+	if err := entry.DecodeOptions(&webhook); err != nil {
+		return nil, err
+	}
+
+	// the entry events take precedence
+	if len(entry.Events) > 0 {
+		webhook.Events = entry.Events
+	}
+
+	if err := webhook.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %v action: %w", entry.DisplayName(), err)
+	}
+
+	return webhookAction{name: entry.DisplayName(), webhook: webhook}, nil
+}
+
+func (a webhookAction) Name() string { return a.name }
+
+func (a webhookAction) Wants(event string) bool { return a.webhook.Wants(event) }
+
+func (a webhookAction) Run(ctx context.Context, event Event) error {
+	sendWebhooks(ctx, a.name, event.Name, []config.Webhook{a.webhook}, event.Session, event.results)
+	return nil
+}
+
+// returns the failure of an action that returned an error
+func actionFailure(action Action, err error, startedAt time.Time) CommandResult {
+	return CommandResult{
+		Source:    action.Name(),
+		Command:   []string{action.Name()},
+		ExitCode:  -1,
+		Err:       err,
+		StartedAt: startedAt,
+		Duration:  time.Since(startedAt),
+	}
+}
@@ -0,0 +1,137 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Bahaaio/pomo/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// records the events it runs on
+type recordAction struct {
+	events chan Event
+	err    error
+}
+
+func (a recordAction) Name() string { return "record" }
+
+func (a recordAction) Wants(event string) bool { return event == "start" }
+
+func (a recordAction) Run(ctx context.Context, event Event) error {
+	a.events <- event
+	return a.err
+}
+
+func TestFromConfig(t *testing.T) {
+	cfg := config.Config{
+		Hooks: config.Hooks{
+			OnStart: []config.Command{{Args: []string{"echo", "start"}}},
+		},
+		Webhooks: []config.Webhook{{URL: "https://example.com", Events: []string{"quit"}}},
+		Actions: []config.Action{
+			{Type: "command", Name: "sync", Events: []string{"pause", "skip"}, Options: map[string]any{"command": []any{"~/sync"}, "timeout": "10s"}},
+			{Type: "webhook", Options: map[string]any{"url": "http://localhost:8080"}},
+			{Type: "webhook", Name: "chat", Options: map[string]any{"url": "http://localhost:9090"}},
+		},
+	}
+
+	actions, err := FromConfig(cfg)
+	require.NoError(t, err)
+
+	var names []string
+	for _, action := range actions {
+		names = append(names, action.Name())
+	}
+	assert.Equal(t, []string{"notification", "then", "onStart", "webhook", "sync", "webhook", "chat"}, names)
+
+	assert.True(t, actions[0].Wants("complete"))
+	assert.False(t, actions[1].Wants("start"))
+	assert.True(t, actions[2].Wants("start"))
+	assert.False(t, actions[3].Wants("complete"))
+	assert.True(t, actions[4].Wants("skip"))
+	assert.False(t, actions[4].Wants("complete"))
+	assert.True(t, actions[5].Wants("longBreak"), "actions without events run on all of them")
+
+	sync := actions[4].(commandAction)
+	assert.Equal(t, 10*time.Second, sync.commands[0].Timeout)
+	assert.NotEqual(t, "~/sync", sync.commands[0].Args[0], "command paths should be expanded")
+}
+
+func TestFromConfigInvalid(t *testing.T) {
+	testCases := []struct {
+		name   string
+		action config.Action
+	}{
+		{"unknown type", config.Action{Type: "slack"}},
+		{"command without command", config.Action{Type: "command", Options: map[string]any{"timeout": "1s"}}},
+		{"unknown option", config.Action{Type: "command", Options: map[string]any{"command": "sync", "retries": 2}}},
+		{"webhook without url", config.Action{Type: "webhook"}},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FromConfig(config.Config{Actions: []config.Action{tt.action}})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	events := make(chan Event, 1)
+
+	Register("test-record", func(entry config.Action) (Action, error) {
+		return recordAction{events: events}, nil
+	})
+	t.Cleanup(func() { delete(registry, "test-record") })
+
+	assert.Panics(t, func() {
+		Register("test-record", nil)
+	}, "types can only be registered once")
+
+	actions, err := FromConfig(config.Config{Actions: []config.Action{{Type: "test-record"}}})
+	require.NoError(t, err)
+
+	runner := NewRunner(actions, nil, nil)
+	runner.Dispatch(Event{Name: "start", Session: testContext})
+	runner.Wait()
+
+	require.Len(t, events, 1)
+	assert.Equal(t, "write report", (<-events).Session.Title)
+}
+
+func TestRunnerDispatch(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	events := make(chan Event, 2)
+	results := make(chan CommandResult, 2)
+
+	runner := NewRunner([]Action{recordAction{events: events, err: errors.New("service unavailable")}}, results, nil)
+
+	assert.True(t, runner.Wants("start"))
+	assert.False(t, runner.Wants("complete"))
+
+	runner.Dispatch(Event{Name: "complete"})
+	runner.Dispatch(Event{Name: "start"})
+	runner.Wait()
+
+	require.Len(t, events, 1, "actions should only run on the events they want")
+	assert.Equal(t, "start", (<-events).Name)
+
+	require.Len(t, results, 1, "errors should be reported")
+	failure := <-results
+	assert.Equal(t, "record", failure.Source)
+	assert.EqualError(t, failure.Err, "service unavailable")
+
+	runner.SetActions(nil)
+	assert.False(t, runner.Wants("start"))
+	assert.False(t, runner.Running())
+}
+
+func TestHookEvent(t *testing.T) {
+	assert.Equal(t, "start", hookEvent("onStart"))
+	assert.Equal(t, "cycleComplete", hookEvent("onCycleComplete"))
+}
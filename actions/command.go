@@ -0,0 +1,140 @@
+package actions
+
+import (
+	"context"
+	"log"
+	"maps"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Bahaaio/pomo/config"
+)
+
+// CommandTimeout is the timeout of foreground commands without one.
+var CommandTimeout = 5 * time.Second
+
+// runs the commands one after another, background commands don't wait.
+// stops at the first failing command unless it continues on error.
+//
+// source names where they come from in the results, e.g. then or onStart
+func runCommands(ctx context.Context, source string, cmds []config.Command, session Context, results chan<- CommandResult) {
+	for _, cmd := range cmds {
+		if cmd.Background {
+			runBackground(source, cmd, session, results)
+			continue
+		}
+
+		result := runForeground(ctx, source, cmd, session)
+
+		logResult(result)
+		report(results, result)
+
+		if result.Failed() {
+			log.Printf("failed to run command '%q': %v\n", result.Command, result.Err)
+
+			if !cmd.ContinueOnError {
+				log.Println("skipping the remaining", source, "commands")
+				return
+			}
+		}
+	}
+}
+
+// runs the command until it exits, times out, or ctx is cancelled
+func runForeground(ctx context.Context, source string, cmd config.Command, session Context) CommandResult {
+	timeout := cmd.Timeout
+	if timeout == 0 {
+		timeout = CommandTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return run(newCommand(ctx, cmd, session), source)
+}
+
+// starts the command detached from the session, its output is discarded
+// so it can keep running after pomo exits
+func runBackground(source string, cmd config.Command, session Context, results chan<- CommandResult) {
+	ctx, cancel := context.Background(), context.CancelFunc(func() {})
+	if cmd.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, cmd.Timeout)
+	}
+
+	c := newCommand(ctx, cmd, session)
+	result := newResult(c, source)
+	detach(c)
+
+	// the output is already discarded when unset, the input is made explicit
+	// since the command can't read from the terminal pomo is drawn on
+	stdin, err := os.Open(os.DevNull)
+	if err == nil {
+		c.Stdin = stdin
+		defer func() { _ = stdin.Close() }() // the command has its own copy once started
+	}
+
+	if err := c.Start(); err != nil {
+		cancel()
+
+		result.finish(err)
+		logResult(result)
+		report(results, result)
+		return
+	}
+
+	go func() {
+		defer cancel()
+
+		result.finish(c.Wait())
+		logResult(result)
+		report(results, result)
+	}()
+}
+
+// builds the command with the session templates expanded,
+// the session and command environment variables, and its options
+//
+// shell scripts aren't expanded since a title could inject commands into them,
+// they read the session from the environment variables instead
+func newCommand(ctx context.Context, cmd config.Command, session Context) *exec.Cmd {
+	args := session.Expand(cmd.Args)
+	if cmd.Shell {
+		args = shellArgs(strings.Join(cmd.Args, " "))
+	}
+
+	c := exec.CommandContext(ctx, args[0], args[1:]...)
+	c.Dir = cmd.Workdir
+	c.Env = append(os.Environ(), session.Env()...)
+
+	for _, key := range slices.Sorted(maps.Keys(cmd.Env)) {
+		c.Env = append(c.Env, key+"="+cmd.Env[key])
+	}
+
+	return c
+}
+
+// returns the arguments to run the script with the system shell
+func shellArgs(script string) []string {
+	if runtime.GOOS == "windows" {
+		return []string{"cmd", "/C", script}
+	}
+
+	return []string{"sh", "-c", script}
+}
+
+// sends the result without blocking, the receiver might be gone
+func report(results chan<- CommandResult, result CommandResult) {
+	if results == nil {
+		return
+	}
+
+	select {
+	case results <- result:
+	default:
+		log.Println("results channel full, dropping result of", result.Command)
+	}
+}
@@ -0,0 +1,98 @@
+package actions
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/Bahaaio/pomo/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCommandsOptions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	workdir := t.TempDir()
+
+	results := make(chan CommandResult, 3)
+	runCommands(context.Background(), "then", []config.Command{
+		{Args: []string{"pwd"}, Workdir: workdir},
+		{Args: []string{"echo", "$NAME", `"$POMO_TITLE"`}, Shell: true, Env: map[string]string{"NAME": "pomo"}},
+		{Args: []string{"sleep", "1"}, Timeout: 10 * time.Millisecond},
+	}, testContext, results)
+
+	require.Len(t, results, 3)
+
+	assert.Equal(t, workdir+"\n", (<-results).Stdout)
+
+	shell := <-results
+	assert.Equal(t, []string{"sh", "-c", `echo $NAME "$POMO_TITLE"`}, shell.Command)
+	assert.Equal(t, "pomo write report\n", shell.Stdout)
+
+	timedOut := <-results
+	assert.True(t, timedOut.Failed(), "command should be killed after its timeout")
+	assert.Less(t, timedOut.Duration, time.Second)
+}
+
+func TestRunCommandsShellTemplates(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	session := testContext
+	session.Title = "report; echo injected"
+
+	results := make(chan CommandResult, 2)
+	runCommands(context.Background(), "then", []config.Command{
+		{Args: []string{"echo", "{{.Title}}"}, Shell: true},
+		{Args: []string{"echo", `"$POMO_TITLE"`}, Shell: true},
+	}, session, results)
+
+	require.Len(t, results, 2)
+	assert.Equal(t, "{{.Title}}\n", (<-results).Stdout, "templates should not be expanded into scripts")
+	assert.Equal(t, "report; echo injected\n", (<-results).Stdout)
+}
+
+func TestRunCommandsStopsOnError(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	results := make(chan CommandResult, 3)
+	runCommands(context.Background(), "onStart", []config.Command{
+		{Args: []string{"pomo-command-that-does-not-exist"}},
+		{Args: []string{"go", "version"}},
+	}, testContext, results)
+
+	require.Len(t, results, 1, "commands after a failure should be skipped")
+	assert.Equal(t, "onStart", (<-results).Source)
+}
+
+func TestRunCommandsBackground(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	results := make(chan CommandResult, 2)
+	start := time.Now()
+
+	runCommands(context.Background(), "then", []config.Command{
+		{Args: []string{"sleep", "0.2"}, Background: true},
+		{Args: []string{"true"}},
+	}, testContext, results)
+
+	assert.Less(t, time.Since(start), 200*time.Millisecond, "background commands should not block the next ones")
+	assert.Equal(t, []string{"true"}, (<-results).Command)
+
+	background := <-results
+	assert.Equal(t, []string{"sleep", "0.2"}, background.Command)
+	assert.False(t, background.Failed())
+	assert.Empty(t, background.Stdout, "background output is discarded")
+}
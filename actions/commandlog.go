@@ -0,0 +1,109 @@
+package actions
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Bahaaio/pomo/config"
+	"github.com/Bahaaio/pomo/xdg"
+)
+
+const (
+	CommandLogFile = "commands.log"
+
+	// the log is rotated to commands.log.1 once it grows past this size
+	maxCommandLogSize = 1024 * 1024
+)
+
+// serializes writes from concurrent commands
+var commandLogMu sync.Mutex
+
+// CommandLogPath returns the path of the command log in the state directory.
+func CommandLogPath() (string, error) {
+	dir, err := xdg.StateHome()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, config.AppName, CommandLogFile), nil
+}
+
+// appends the result to the command log, rotating it if needed
+func logResult(result CommandResult) {
+	path, err := CommandLogPath()
+	if err != nil {
+		log.Println("could not get command log path:", err)
+		return
+	}
+
+	commandLogMu.Lock()
+	defer commandLogMu.Unlock()
+
+	if err := writeLogEntry(path, result); err != nil {
+		log.Println("failed to write command log:", err)
+	}
+}
+
+func writeLogEntry(path string, result CommandResult) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	if info, err := os.Stat(path); err == nil && info.Size() > maxCommandLogSize {
+		if err := os.Rename(path, path+".1"); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(formatLogEntry(result))
+	return err
+}
+
+// formats a result as a log entry, e.g.
+//
+//	2025-01-02T15:04:05+02:00 [then] exit=1 duration=12ms notify-send done
+//	  stderr: invalid option
+func formatLogEntry(result CommandResult) string {
+	var builder strings.Builder
+
+	status := fmt.Sprintf("exit=%d", result.ExitCode)
+	if result.ExitCode < 0 {
+		status = fmt.Sprintf("error=%q", result.Err.Error())
+	}
+
+	fmt.Fprintf(&builder, "%v [%v] %v duration=%v %v\n",
+		result.StartedAt.Format(time.RFC3339),
+		result.Source,
+		status,
+		result.Duration.Round(time.Millisecond),
+		strings.Join(quoteArgs(result.Command), " "),
+	)
+
+	writeOutput(&builder, "stdout", result.Stdout)
+	writeOutput(&builder, "stderr", result.Stderr)
+
+	return builder.String()
+}
+
+// writes indented command output
+func writeOutput(builder *strings.Builder, name, output string) {
+	output = strings.TrimRight(output, "\n")
+	if output == "" {
+		return
+	}
+
+	for line := range strings.SplitSeq(output, "\n") {
+		fmt.Fprintf(builder, "  %v: %v\n", name, line)
+	}
+}
@@ -0,0 +1,119 @@
+package actions
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Context describes the session an action runs for.
+// commands get it as POMO_ environment variables,
+// commands and notifications can use its fields in Go templates, e.g. {{.Title}}
+type Context struct {
+	TaskType      string // work or break
+	Title         string
+	Elapsed       time.Duration
+	Planned       time.Duration
+	Cycle         int // position in the long break cycle
+	CycleLength   int // work sessions per long break, 0 if long breaks are disabled
+	IsLongBreak   bool
+	LongBreakNext bool          // the next session is a long break
+	TodayTotal    time.Duration // work time recorded today
+	TodayCount    int           // completed work sessions recorded today
+	Goal          int           // daily goal of work sessions, 0 if there is none
+	StartedAt     time.Time
+	Idle          time.Duration // time since the session ended, for reminders
+}
+
+// GoalLeft returns the work sessions left to reach the daily goal.
+func (c Context) GoalLeft() int {
+	return max(c.Goal-c.TodayCount, 0)
+}
+
+// functions available in templates
+var templateFuncs = template.FuncMap{
+	// formats a duration rounded to the minute, e.g. {{duration .TodayTotal}} -> 1h15m
+	"duration": formatDuration,
+}
+
+// Env returns the context as environment variables,
+// durations are in whole seconds.
+func (c Context) Env() []string {
+	return []string{
+		"POMO_TASK_TYPE=" + c.TaskType,
+		"POMO_TITLE=" + c.Title,
+		"POMO_ELAPSED=" + seconds(c.Elapsed),
+		"POMO_PLANNED=" + seconds(c.Planned),
+		"POMO_CYCLE=" + strconv.Itoa(c.Cycle),
+		"POMO_CYCLE_LENGTH=" + strconv.Itoa(c.CycleLength),
+		"POMO_IS_LONG_BREAK=" + strconv.FormatBool(c.IsLongBreak),
+		"POMO_LONG_BREAK_NEXT=" + strconv.FormatBool(c.LongBreakNext),
+		"POMO_TODAY_TOTAL=" + seconds(c.TodayTotal),
+		"POMO_TODAY_COUNT=" + strconv.Itoa(c.TodayCount),
+		"POMO_GOAL=" + strconv.Itoa(c.Goal),
+		"POMO_IDLE=" + seconds(c.Idle),
+	}
+}
+
+// Expand executes the templates in the command arguments,
+// arguments that fail to execute are left as is.
+func (c Context) Expand(cmd []string) []string {
+	expanded := make([]string, len(cmd))
+
+	for i, arg := range cmd {
+		expanded[i] = c.Render(arg)
+	}
+
+	return expanded
+}
+
+// Render executes the template in text,
+// text that fails to execute is returned as is.
+func (c Context) Render(text string) string {
+	if !strings.Contains(text, "{{") {
+		return text
+	}
+
+	result, err := c.execute(text)
+	if err != nil {
+		log.Printf("failed to expand template %q: %v", text, err)
+		return text
+	}
+
+	return result
+}
+
+func (c Context) execute(text string) (string, error) {
+	tmpl, err := template.New("text").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	if err := tmpl.Execute(&builder, c); err != nil {
+		return "", err
+	}
+
+	return builder.String(), nil
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64(d.Seconds()), 10)
+}
+
+// formats a duration rounded to the minute, e.g. "1h15m"
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+
+	if hours == 0 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+
+	return fmt.Sprintf("%dh%02dm", hours, minutes)
+}
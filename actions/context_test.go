@@ -0,0 +1,71 @@
+package actions
+
+import (
+	"io"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+var testContext = Context{
+	TaskType:    "work",
+	Title:       "write report",
+	Elapsed:     25*time.Minute + 30*time.Second,
+	Planned:     25 * time.Minute,
+	Cycle:       2,
+	CycleLength: 4,
+	IsLongBreak: false,
+	TodayTotal:  time.Hour + 15*time.Minute,
+	TodayCount:  3,
+	Goal:        8,
+}
+
+func TestEnv(t *testing.T) {
+	assert.Equal(t, []string{
+		"POMO_TASK_TYPE=work",
+		"POMO_TITLE=write report",
+		"POMO_ELAPSED=1530",
+		"POMO_PLANNED=1500",
+		"POMO_CYCLE=2",
+		"POMO_CYCLE_LENGTH=4",
+		"POMO_IS_LONG_BREAK=false",
+		"POMO_LONG_BREAK_NEXT=false",
+		"POMO_TODAY_TOTAL=4500",
+		"POMO_TODAY_COUNT=3",
+		"POMO_GOAL=8",
+		"POMO_IDLE=0",
+	}, testContext.Env())
+}
+
+func TestExpand(t *testing.T) {
+	testCases := []struct {
+		name     string
+		arg      string
+		expected string
+	}{
+		{"plain argument", "--verbose", "--verbose"},
+		{"title", "finished {{.Title}}", "finished write report"},
+		{"duration method", "{{.Elapsed.Minutes | printf \"%.0f\"}}m", "26m"},
+		{"multiple fields", "{{.TaskType}} #{{.Cycle}}", "work #2"},
+		{"duration function", "{{duration .TodayTotal}} today", "1h15m today"},
+		{"goal left", "{{.TodayCount}}/{{.Goal}}, {{.GoalLeft}} to go", "3/8, 5 to go"},
+		{"condition", "{{if .LongBreakNext}}long break next{{else}}{{.Cycle}}/{{.CycleLength}}{{end}}", "2/4"},
+		{"unknown field is kept", "{{.Project}}", "{{.Project}}"},
+		{"malformed template is kept", "{{.Title", "{{.Title"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			expanded := testContext.Expand([]string{"echo", tt.arg})
+			assert.Equal(t, []string{"echo", tt.expected}, expanded)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package actions
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// starts the command in a new session, so it isn't hung up
+// along with pomo when its terminal or tmux pane closes
+func detach(c *exec.Cmd) {
+	c.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
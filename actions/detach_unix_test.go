@@ -0,0 +1,63 @@
+//go:build !windows
+
+package actions
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Bahaaio/pomo/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackgroundCommandOutlivesParent(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "done")
+
+	// runs the helper below as the parent, in its own process group like a shell job
+	parent := exec.Command(os.Args[0], "-test.run=^TestBackgroundParentHelper$")
+	parent.Env = append(os.Environ(),
+		"POMO_TEST_BACKGROUND_PARENT=1",
+		"POMO_TEST_MARKER="+marker,
+		"XDG_STATE_HOME="+t.TempDir(),
+	)
+	parent.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	stdout, err := parent.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, parent.Start())
+
+	line, err := bufio.NewReader(stdout).ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "started\n", line)
+
+	// hangs up the parent's process group, as closing its terminal would
+	require.NoError(t, syscall.Kill(-parent.Process.Pid, syscall.SIGHUP))
+	_ = parent.Wait()
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(marker)
+		return err == nil
+	}, 3*time.Second, 20*time.Millisecond, "the background command should keep running after the parent is hung up")
+}
+
+// starts a background command and waits to be hung up, see TestBackgroundCommandOutlivesParent
+func TestBackgroundParentHelper(t *testing.T) {
+	if os.Getenv("POMO_TEST_BACKGROUND_PARENT") == "" {
+		t.Skip("only runs as a helper process")
+	}
+
+	runBackground("then", config.Command{
+		Args:       []string{"sh", "-c", `sleep 0.3; touch "$POMO_TEST_MARKER"`},
+		Background: true,
+	}, testContext, nil)
+
+	fmt.Println("started")
+	time.Sleep(time.Minute)
+}
@@ -0,0 +1,19 @@
+//go:build windows
+
+package actions
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// not defined by the syscall package
+const detachedProcess = 0x00000008
+
+// starts the command without a console in a new process group,
+// so it isn't closed along with pomo's console
+func detach(c *exec.Cmd) {
+	c.SysProcAttr = &syscall.SysProcAttr{
+		CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP | detachedProcess,
+	}
+}
@@ -0,0 +1,206 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/Bahaaio/pomo/config"
+	"github.com/gen2brain/beeep"
+)
+
+// opens the terminal that receives the escape sequences of the terminal backends.
+// it's opened apart from stdout, which the timer draws on from its own goroutine
+var openTerminal = func() (io.WriteCloser, error) {
+	name := "/dev/tty"
+	if runtime.GOOS == "windows" {
+		name = "CONOUT$"
+	}
+
+	return os.OpenFile(name, os.O_WRONLY, 0)
+}
+
+// Notice is a notification delivered by the banner backend.
+type Notice struct {
+	Title   string
+	Message string
+	Urgent  bool
+}
+
+// Notifier delivers task notifications through the configured backends.
+type Notifier struct {
+	config.Notifications
+
+	// receives the notifications of the banner backend
+	Banners chan<- Notice
+}
+
+// Send executes the templates of the notification with the session context
+// and sends it using the first backend that succeeds.
+func (n Notifier) Send(ctx context.Context, notification config.Notification, session Context, results chan<- CommandResult) {
+	if !notification.Enabled {
+		log.Println("notification disabled")
+		return
+	}
+
+	notification.Title = session.Render(notification.Title)
+	notification.Message = session.Render(notification.Message)
+
+	for _, backend := range n.Backends {
+		err := n.deliver(ctx, backend, notification, session, results)
+		if err == nil {
+			log.Println("sent notification using", backend)
+			return
+		}
+
+		log.Printf("failed to send notification using %v: %v", backend, err)
+	}
+
+	log.Println("no notification backend succeeded")
+}
+
+func (n Notifier) deliver(ctx context.Context, backend string, notification config.Notification, session Context, results chan<- CommandResult) error {
+	switch backend {
+	case "desktop":
+		return notifyDesktop(notification)
+	case "osc9":
+		return writeTerminal(osc9(notification))
+	case "osc777":
+		return writeTerminal(osc777(notification))
+	case "bell":
+		return writeTerminal("\a")
+	case "banner":
+		return n.notifyBanner(notification)
+	case "command":
+		return n.notifyCommand(ctx, notification, session, results)
+	default:
+		return errors.New("unknown backend")
+	}
+}
+
+// sends a system notification using the beeep package
+func notifyDesktop(notification config.Notification) error {
+	if isRemote() {
+		return errors.New("no display over SSH")
+	}
+
+	// use the embedded icon
+	var icon any = config.Icon
+
+	// if the user has specified an icon
+	// use that instead
+	if len(notification.Icon) > 0 {
+		icon = notification.Icon
+	}
+
+	if notification.Urgent {
+		return beeep.Alert(notification.Title, notification.Message, icon)
+	}
+
+	return beeep.Notify(notification.Title, notification.Message, icon)
+}
+
+// reports whether pomo runs over SSH without a forwarded display,
+// desktop notifications would show on the remote machine, if anywhere
+func isRemote() bool {
+	overSSH := os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_TTY") != ""
+	hasDisplay := os.Getenv("DISPLAY") != "" || os.Getenv("WAYLAND_DISPLAY") != ""
+
+	return overSSH && !hasDisplay
+}
+
+// returns the OSC 9 sequence, it only has a message so the title is prepended
+func osc9(notification config.Notification) string {
+	text := notification.Title
+	if notification.Message != "" {
+		text += ": " + notification.Message
+	}
+
+	return "\x1b]9;" + sanitize(text) + "\a"
+}
+
+// returns the OSC 777 sequence, semicolons separate its fields so they are replaced in the title
+func osc777(notification config.Notification) string {
+	title := strings.ReplaceAll(sanitize(notification.Title), ";", ",")
+	return "\x1b]777;notify;" + title + ";" + sanitize(notification.Message) + "\a"
+}
+
+// removes control characters that would end the escape sequence early
+func sanitize(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, text)
+}
+
+// writes the sequence to the terminal,
+// inside tmux escape sequences are wrapped so they reach the outer terminal
+func writeTerminal(sequence string) error {
+	terminal, err := openTerminal()
+	if err != nil {
+		return fmt.Errorf("no terminal: %w", err)
+	}
+	defer func() { _ = terminal.Close() }()
+
+	if os.Getenv("TMUX") != "" && strings.HasPrefix(sequence, "\x1b") {
+		sequence = tmuxPassthrough(sequence)
+	}
+
+	// a single write, so it isn't split by the frames of the timer
+	_, err = io.WriteString(terminal, sequence)
+	return err
+}
+
+// wraps the sequence in a tmux DCS passthrough, escape characters inside it are doubled.
+// requires `set -g allow-passthrough on` since tmux 3.3
+func tmuxPassthrough(sequence string) string {
+	return "\x1bPtmux;" + strings.ReplaceAll(sequence, "\x1b", "\x1b\x1b") + "\x1b\\"
+}
+
+// sends the notification to the timer without blocking
+func (n Notifier) notifyBanner(notification config.Notification) error {
+	if n.Banners == nil {
+		return errors.New("no timer to show the banner")
+	}
+
+	select {
+	case n.Banners <- Notice{Title: notification.Title, Message: notification.Message, Urgent: notification.Urgent}:
+		return nil
+	default:
+		return errors.New("banner channel full")
+	}
+}
+
+// runs the notification command with the title and message as environment variables
+func (n Notifier) notifyCommand(ctx context.Context, notification config.Notification, session Context, results chan<- CommandResult) error {
+	cmd := n.Command
+	if len(cmd.Args) == 0 {
+		return errors.New("no notification command")
+	}
+
+	cmd.Env = maps.Clone(cmd.Env)
+	if cmd.Env == nil {
+		cmd.Env = map[string]string{}
+	}
+	cmd.Env["POMO_NOTIFICATION_TITLE"] = notification.Title
+	cmd.Env["POMO_NOTIFICATION_MESSAGE"] = notification.Message
+
+	result := runForeground(ctx, "notification", cmd, session)
+
+	logResult(result)
+	report(results, result)
+
+	if result.Failed() {
+		return result.Err
+	}
+
+	return nil
+}
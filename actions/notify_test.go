@@ -0,0 +1,144 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"runtime"
+	"testing"
+
+	"github.com/Bahaaio/pomo/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNotification = config.Notification{
+	Enabled: true,
+	Title:   "work finished; nice",
+	Message: "time to take a break",
+}
+
+// replaces the terminal with a buffer for the test, outside of tmux
+func captureTerminal(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	t.Setenv("TMUX", "")
+
+	previous := openTerminal
+	openTerminal = func() (io.WriteCloser, error) { return nopCloser{&buf}, nil }
+	t.Cleanup(func() { openTerminal = previous })
+
+	return &buf
+}
+
+type nopCloser struct{ io.Writer }
+
+func (nopCloser) Close() error { return nil }
+
+func TestNotifyTerminal(t *testing.T) {
+	testCases := []struct {
+		name     string
+		backend  string
+		tmux     string
+		expected string
+	}{
+		{"osc9", "osc9", "", "\x1b]9;work finished; nice: time to take a break\a"},
+		{"osc777", "osc777", "", "\x1b]777;notify;work finished, nice;time to take a break\a"},
+		{"bell", "bell", "", "\a"},
+		{"osc9 in tmux", "osc9", "/tmp/tmux-1000/default", "\x1bPtmux;\x1b\x1b]9;work finished; nice: time to take a break\a\x1b\\"},
+		{"bell in tmux", "bell", "/tmp/tmux-1000/default", "\a"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := captureTerminal(t)
+			t.Setenv("TMUX", tt.tmux)
+
+			notifier := Notifier{Notifications: config.Notifications{Backends: []string{tt.backend}}}
+			notifier.Send(context.Background(), testNotification, testContext, nil)
+
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestNotifyTemplates(t *testing.T) {
+	buf := captureTerminal(t)
+
+	notification := config.Notification{
+		Enabled: true,
+		Title:   "{{.Title}} done",
+		Message: "{{.TodayCount}}/{{.Goal}}, {{duration .TodayTotal}} today",
+	}
+
+	notifier := Notifier{Notifications: config.Notifications{Backends: []string{"osc777"}}}
+	notifier.Send(context.Background(), notification, testContext, nil)
+
+	assert.Equal(t, "\x1b]777;notify;write report done;3/8, 1h15m today\a", buf.String())
+}
+
+func TestSanitize(t *testing.T) {
+	assert.Equal(t, "done]9;x", sanitize("done\a\x1b]9;x\n"))
+}
+
+func TestNotifyFallback(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	t.Setenv("SSH_CONNECTION", "10.0.0.2 52000 10.0.0.1 22")
+	t.Setenv("DISPLAY", "")
+	t.Setenv("WAYLAND_DISPLAY", "")
+
+	banners := make(chan Notice, 1)
+	results := make(chan CommandResult, 1)
+
+	notifier := Notifier{
+		Notifications: config.Notifications{
+			Backends: []string{"desktop", "command", "banner", "bell"},
+			Command:  config.Command{Args: []string{`test "$POMO_NOTIFICATION_TITLE" = nope`}, Shell: true},
+		},
+		Banners: banners,
+	}
+
+	buf := captureTerminal(t)
+	notifier.Send(context.Background(), testNotification, testContext, results)
+
+	require.Len(t, results, 1, "the failing command should be reported")
+	assert.Equal(t, "notification", (<-results).Source)
+
+	require.Len(t, banners, 1, "the banner should be used after desktop and command fail")
+	assert.Equal(t, Notice{Title: testNotification.Title, Message: testNotification.Message}, <-banners)
+
+	assert.Empty(t, buf.String(), "backends after the first success should be skipped")
+}
+
+func TestNotifyCommandEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	results := make(chan CommandResult, 1)
+	notifier := Notifier{
+		Notifications: config.Notifications{
+			Backends: []string{"command"},
+			Command:  config.Command{Args: []string{`echo "$POMO_NOTIFICATION_TITLE|$POMO_NOTIFICATION_MESSAGE"`}, Shell: true},
+		},
+	}
+
+	notifier.Send(context.Background(), testNotification, testContext, results)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, "work finished; nice|time to take a break\n", (<-results).Stdout)
+}
+
+func TestNotifyDisabled(t *testing.T) {
+	buf := captureTerminal(t)
+
+	notifier := Notifier{Notifications: config.Notifications{Backends: []string{"bell"}}}
+	notifier.Send(context.Background(), config.Notification{Enabled: false}, testContext, nil)
+
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,118 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// maximum captured output of a command stream
+const maxOutputSize = 64 * 1024
+
+// CommandResult is the outcome of a single command.
+type CommandResult struct {
+	Source    string // then or the hook name
+	Command   []string
+	Stdout    string
+	Stderr    string
+	ExitCode  int // -1 if the command didn't exit on its own, e.g. not found or timed out
+	Err       error
+	StartedAt time.Time
+	Duration  time.Duration
+}
+
+// Failed reports whether the command failed to run or exited with a non-zero status.
+func (r CommandResult) Failed() bool {
+	return r.Err != nil
+}
+
+// Summary returns a one line description of the failure,
+// e.g. "notify-send 'done' exited with 1: invalid option"
+func (r CommandResult) Summary() string {
+	summary := strings.Join(quoteArgs(r.Command), " ")
+
+	if r.ExitCode >= 0 {
+		summary += fmt.Sprintf(" exited with %d", r.ExitCode)
+	} else {
+		summary += ": " + r.Err.Error()
+	}
+
+	if line := firstLine(r.Stderr); line != "" {
+		summary += ": " + line
+	}
+
+	return summary
+}
+
+// runs the command and captures its output and exit status
+func run(c *exec.Cmd, source string) CommandResult {
+	var stdout, stderr cappedBuffer
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+
+	result := newResult(c, source)
+	result.finish(c.Run())
+
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+
+	return result
+}
+
+func newResult(c *exec.Cmd, source string) CommandResult {
+	return CommandResult{
+		Source:    source,
+		Command:   c.Args,
+		StartedAt: time.Now(),
+	}
+}
+
+// sets the duration and exit status from the error returned by the command
+func (result *CommandResult) finish(err error) {
+	result.Err = err
+	result.Duration = time.Since(result.StartedAt)
+
+	if result.Err != nil {
+		result.ExitCode = -1
+
+		var exitErr *exec.ExitError
+		if errors.As(result.Err, &exitErr) && exitErr.Exited() {
+			result.ExitCode = exitErr.ExitCode()
+		}
+	}
+}
+
+// quotes arguments containing spaces
+func quoteArgs(args []string) []string {
+	quoted := make([]string, len(args))
+
+	for i, arg := range args {
+		if strings.ContainsAny(arg, " \t") {
+			arg = "'" + arg + "'"
+		}
+		quoted[i] = arg
+	}
+
+	return quoted
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(line)
+}
+
+// a writer that keeps the first maxOutputSize bytes
+type cappedBuffer struct {
+	strings.Builder
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if remaining := maxOutputSize - b.Len(); remaining > 0 {
+		b.Builder.Write(p[:min(len(p), remaining)])
+	}
+
+	// pretend everything was written so the command doesn't fail
+	return len(p), nil
+}
@@ -0,0 +1,105 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Bahaaio/pomo/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCommandsResults(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	results := make(chan CommandResult, 3)
+	runCommands(context.Background(), "then", []config.Command{
+		{Args: []string{"sh", "-c", "echo $POMO_TITLE"}},
+		{Args: []string{"sh", "-c", "echo oops >&2; exit 3"}, ContinueOnError: true},
+		{Args: []string{"pomo-command-that-does-not-exist"}},
+	}, testContext, results)
+
+	ok := <-results
+	assert.False(t, ok.Failed())
+	assert.Equal(t, "write report\n", ok.Stdout, "commands should get the session env")
+	assert.Equal(t, "then", ok.Source)
+
+	failed := <-results
+	assert.True(t, failed.Failed())
+	assert.Equal(t, 3, failed.ExitCode)
+	assert.Equal(t, "sh -c 'echo oops >&2; exit 3' exited with 3: oops", failed.Summary())
+
+	missing := <-results
+	assert.True(t, missing.Failed())
+	assert.Equal(t, -1, missing.ExitCode)
+
+	path, err := CommandLogPath()
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "[then] exit=0")
+	assert.Contains(t, string(data), "  stderr: oops\n")
+}
+
+func TestReportDoesNotBlock(t *testing.T) {
+	results := make(chan CommandResult, 1)
+
+	report(results, CommandResult{})
+	report(results, CommandResult{}) // full, dropped
+	report(nil, CommandResult{})
+
+	assert.Len(t, results, 1)
+}
+
+func TestFormatLogEntry(t *testing.T) {
+	startedAt := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	entry := formatLogEntry(CommandResult{
+		Source:    "onStart",
+		Command:   []string{"notify-send", "work done"},
+		Stdout:    "line 1\nline 2\n",
+		ExitCode:  -1,
+		Err:       errors.New("signal: killed"),
+		StartedAt: startedAt,
+		Duration:  5 * time.Second,
+	})
+
+	expected := `2025-01-02T15:04:05Z [onStart] error="signal: killed" duration=5s notify-send 'work done'
+  stdout: line 1
+  stdout: line 2
+`
+	assert.Equal(t, expected, entry)
+}
+
+func TestCommandLogRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), CommandLogFile)
+	require.NoError(t, os.WriteFile(path, []byte(strings.Repeat("x", maxCommandLogSize+1)), 0o644))
+
+	require.NoError(t, writeLogEntry(path, CommandResult{Command: []string{"true"}}))
+
+	assert.FileExists(t, path+".1")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Less(t, info.Size(), int64(maxCommandLogSize), "a new log should be started")
+}
+
+func TestCappedBuffer(t *testing.T) {
+	var buffer cappedBuffer
+
+	n, err := buffer.Write([]byte(strings.Repeat("x", maxOutputSize+10)))
+	assert.NoError(t, err)
+	assert.Equal(t, maxOutputSize+10, n)
+	assert.Equal(t, maxOutputSize, buffer.Len())
+}
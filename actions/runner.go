@@ -0,0 +1,133 @@
+package actions
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Runner runs actions in the background on session events.
+type Runner struct {
+	mu      sync.Mutex
+	actions []Action
+
+	wg      sync.WaitGroup
+	running atomic.Int32
+	ctx     context.Context
+	cancel  context.CancelFunc
+
+	results chan<- CommandResult
+	banners chan<- Notice
+}
+
+// NewRunner returns a runner of the actions, the results of their commands and requests
+// are sent to results and the notifications of the banner backend to banners.
+func NewRunner(actions []Action, results chan<- CommandResult, banners chan<- Notice) *Runner {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Runner{
+		actions: actions,
+		ctx:     ctx,
+		cancel:  cancel,
+		results: results,
+		banners: banners,
+	}
+}
+
+// SetActions replaces the actions of the next events, running actions keep running.
+func (r *Runner) SetActions(actions []Action) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.actions = actions
+}
+
+// Wants reports whether any action runs on the event.
+func (r *Runner) Wants(event string) bool {
+	return len(r.wanting(event)) > 0
+}
+
+// returns the actions that run on the event
+func (r *Runner) wanting(event string) []Action {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var actions []Action
+	for _, action := range r.actions {
+		if action.Wants(event) {
+			actions = append(actions, action)
+		}
+	}
+
+	return actions
+}
+
+// Dispatch starts the actions that run on the event without waiting for them.
+// the returned function cancels them, e.g. when the next session starts.
+func (r *Runner) Dispatch(event Event) context.CancelFunc {
+	ctx, cancel := context.WithCancel(r.ctx)
+
+	actions := r.wanting(event.Name)
+	if len(actions) == 0 {
+		return cancel
+	}
+
+	log.Printf("running %d actions on %v", len(actions), event.Name)
+
+	event.results = r.results
+	event.banners = r.banners
+
+	var dispatched sync.WaitGroup
+	for _, action := range actions {
+		dispatched.Add(1)
+
+		r.goRun(func() {
+			defer dispatched.Done()
+			r.run(ctx, action, event)
+		})
+	}
+
+	// release the context once the actions are done
+	go func() {
+		dispatched.Wait()
+		cancel()
+	}()
+
+	return cancel
+}
+
+// runs the action and reports its error
+func (r *Runner) run(ctx context.Context, action Action, event Event) {
+	startedAt := time.Now()
+
+	if err := action.Run(ctx, event); err != nil {
+		log.Printf("%v action failed on %v: %v", action.Name(), event.Name, err)
+		event.Report(actionFailure(action, err, startedAt))
+	}
+}
+
+// runs f in a goroutine tracked by Running and Wait
+func (r *Runner) goRun(f func()) {
+	r.running.Add(1)
+	r.wg.Go(func() {
+		defer r.running.Add(-1)
+		f()
+	})
+}
+
+// Running reports whether any action is still running.
+func (r *Runner) Running() bool {
+	return r.running.Load() > 0
+}
+
+// Wait blocks until all running actions are done.
+func (r *Runner) Wait() {
+	r.wg.Wait()
+}
+
+// Cancel stops all running actions.
+func (r *Runner) Cancel() {
+	r.cancel()
+}
@@ -0,0 +1,147 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/Bahaaio/pomo/config"
+)
+
+// default delay before the first webhook retry
+const webhookBackoff = time.Second
+
+// WebhookEvent is the JSON body sent to webhooks.
+type WebhookEvent struct {
+	Event       string    `json:"event"`
+	TaskType    string    `json:"taskType"`
+	Title       string    `json:"title"`
+	Elapsed     int64     `json:"elapsed"` // seconds
+	Planned     int64     `json:"planned"` // seconds
+	Cycle       int       `json:"cycle"`
+	IsLongBreak bool      `json:"isLongBreak"`
+	StartedAt   time.Time `json:"startedAt"` // when the session started
+	Timestamp   time.Time `json:"timestamp"` // when the event happened
+}
+
+func newWebhookEvent(event string, session Context) WebhookEvent {
+	return WebhookEvent{
+		Event:       event,
+		TaskType:    session.TaskType,
+		Title:       session.Title,
+		Elapsed:     int64(session.Elapsed.Seconds()),
+		Planned:     int64(session.Planned.Seconds()),
+		Cycle:       session.Cycle,
+		IsLongBreak: session.IsLongBreak,
+		StartedAt:   session.StartedAt,
+		Timestamp:   time.Now(),
+	}
+}
+
+// sends the event to every webhook that wants it, one after another.
+// failures are logged and sent to results like failed commands.
+//
+// source names where they come from in the results, e.g. webhook or the action name
+func sendWebhooks(ctx context.Context, source, event string, webhooks []config.Webhook, session Context, results chan<- CommandResult) {
+	body, err := json.Marshal(newWebhookEvent(event, session))
+	if err != nil {
+		log.Println("failed to encode webhook event:", err)
+		return
+	}
+
+	for _, webhook := range webhooks {
+		if !webhook.Wants(event) {
+			continue
+		}
+
+		result := CommandResult{
+			Source:    source,
+			Command:   []string{"POST", webhook.URL},
+			StartedAt: time.Now(),
+		}
+
+		err := sendWebhook(ctx, webhook, body)
+		result.Duration = time.Since(result.StartedAt)
+
+		if err != nil {
+			log.Printf("failed to send %v webhook to %v: %v", event, webhook.URL, err)
+			result.Err = err
+			result.ExitCode = -1
+		}
+
+		logResult(result)
+		report(results, result)
+	}
+}
+
+// POSTs the body, retrying on network errors and 5xx or 429 responses
+// with an exponential backoff until the retries run out or ctx is cancelled
+func sendWebhook(ctx context.Context, webhook config.Webhook, body []byte) error {
+	backoff := webhook.Backoff
+	if backoff == 0 {
+		backoff = webhookBackoff
+	}
+
+	var err error
+
+	for attempt := 0; ; attempt++ {
+		var retry bool
+		retry, err = post(ctx, webhook, body)
+
+		if err == nil || !retry || attempt >= webhook.Retries {
+			return err
+		}
+
+		log.Printf("webhook %v failed: %v, retrying in %v", webhook.URL, err, backoff)
+
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-ctx.Done():
+			return fmt.Errorf("%w (cancelled before retry: %w)", err, ctx.Err())
+		}
+	}
+}
+
+// sends a single request, returns whether it's worth retrying on failure
+func post(ctx context.Context, webhook config.Webhook, body []byte) (bool, error) {
+	timeout := webhook.Timeout
+	if timeout == 0 {
+		timeout = CommandTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", config.AppName)
+
+	for key, value := range webhook.Headers {
+		req.Header.Set(key, os.ExpandEnv(value))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// retrying won't help once pomo cancelled the actions
+		return !errors.Is(ctx.Err(), context.Canceled), err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("unexpected status %v", resp.Status)
+}
@@ -0,0 +1,117 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Bahaaio/pomo/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendWebhooks(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	t.Setenv("POMO_TEST_TOKEN", "secret")
+
+	var received WebhookEvent
+	var header http.Header
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	results := make(chan CommandResult, 2)
+	webhooks := []config.Webhook{
+		{URL: server.URL, Headers: map[string]string{"Authorization": "Bearer $POMO_TEST_TOKEN"}},
+		{URL: server.URL + "/ignored", Events: []string{"start"}},
+	}
+
+	sendWebhooks(context.Background(), "chat", "complete", webhooks, testContext, results)
+
+	require.Len(t, results, 1, "webhooks should only be sent for the events they want")
+	result := <-results
+	assert.False(t, result.Failed())
+	assert.Equal(t, "chat", result.Source, "results should be named after their action")
+
+	assert.Equal(t, "application/json", header.Get("Content-Type"))
+	assert.Equal(t, "Bearer secret", header.Get("Authorization"), "header values should expand env vars")
+
+	assert.Equal(t, "complete", received.Event)
+	assert.Equal(t, "work", received.TaskType)
+	assert.Equal(t, "write report", received.Title)
+	assert.Equal(t, int64(1530), received.Elapsed)
+	assert.Equal(t, int64(1500), received.Planned)
+	assert.Equal(t, 2, received.Cycle)
+	assert.WithinDuration(t, time.Now(), received.Timestamp, time.Minute)
+}
+
+func TestSendWebhookRetries(t *testing.T) {
+	testCases := []struct {
+		name          string
+		statuses      []int
+		retries       int
+		expectErr     bool
+		expectedCalls int32
+	}{
+		{"success", []int{200}, 3, false, 1},
+		{"retries server errors", []int{500, 503, 204}, 3, false, 3},
+		{"retries rate limits", []int{429, 200}, 1, false, 2},
+		{"gives up after retries", []int{500, 500, 500}, 2, true, 3},
+		{"does not retry client errors", []int{400, 200}, 3, true, 1},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := calls.Add(1)
+				w.WriteHeader(tt.statuses[min(int(call), len(tt.statuses))-1])
+			}))
+			defer server.Close()
+
+			webhook := config.Webhook{URL: server.URL, Retries: tt.retries, Backoff: time.Millisecond}
+			err := sendWebhook(context.Background(), webhook, []byte("{}"))
+
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedCalls, calls.Load())
+		})
+	}
+}
+
+func TestSendWebhookTimeoutAndCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(500 * time.Millisecond):
+		}
+	}))
+	defer server.Close()
+
+	webhook := config.Webhook{URL: server.URL, Timeout: 10 * time.Millisecond}
+
+	start := time.Now()
+	assert.Error(t, sendWebhook(context.Background(), webhook, []byte("{}")))
+	assert.Less(t, time.Since(start), 400*time.Millisecond, "request should time out")
+
+	// cancelled actions stop retrying
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	webhook.Retries = 5
+	webhook.Backoff = time.Hour
+
+	assert.Error(t, sendWebhook(ctx, webhook, []byte("{}")))
+}
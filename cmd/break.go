@@ -21,5 +21,7 @@ var breakCmd = &cobra.Command{
 }
 
 func init() {
+	addCompactFlag(breakCmd)
+
 	rootCmd.AddCommand(breakCmd)
 }
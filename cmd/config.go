@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"text/tabwriter"
+
+	"github.com/Bahaaio/pomo/actions"
+	"github.com/Bahaaio/pomo/config"
+	"github.com/Bahaaio/pomo/db"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the pomo configuration",
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Args:  cobra.NoArgs,
+	Short: "Show the effective configuration",
+	Long: `Show the effective configuration after merging all config files
+and POMO_* environment variables.
+
+Config files are merged in this order, later ones take precedence:
+system, user, project (.pomo.yaml up to the repository root), local (./pomo.yaml)`,
+	Example: `  pomo config show           # Show every config value
+  pomo config show --origin  # Show where each value came from`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		showOrigin, _ := cmd.Flags().GetBool("origin")
+
+		if showOrigin {
+			for _, layer := range config.Layers() {
+				fmt.Println("# using", layer)
+			}
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+		for _, setting := range config.Settings() {
+			line := setting.Key + "\t" + formatValue(setting.Value)
+			if showOrigin {
+				line += "\t" + setting.Origin
+			}
+
+			_, _ = fmt.Fprintln(w, line)
+		}
+
+		_ = w.Flush()
+	},
+}
+
+var configPathsCmd = &cobra.Command{
+	Use:   "paths",
+	Args:  cobra.NoArgs,
+	Short: "Show the resolved config, state and data paths",
+	Long: `Show the resolved config, state and data paths.
+
+On Linux and macOS, $XDG_CONFIG_HOME, $XDG_CONFIG_DIRS, $XDG_STATE_HOME,
+$XDG_DATA_HOME and $XDG_DATA_DIRS are honored.`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+		printPath := func(name, path string, err error) {
+			if err != nil {
+				_, _ = fmt.Fprintf(w, "%s\t(%v)\n", name, err)
+				return
+			}
+
+			status := ""
+			if _, err := os.Stat(path); err != nil {
+				status = "(not found)"
+			}
+
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", name, path, status)
+		}
+
+		for _, path := range config.SystemConfigFiles() {
+			printPath("system config", path, nil)
+		}
+
+		userConfig, err := config.UserConfigFile()
+		printPath("user config", userConfig, err)
+
+		for _, layer := range config.Layers() {
+			if layer.Name == "project" || layer.Name == "local" {
+				printPath(layer.Name+" config", layer.Path, nil)
+			}
+		}
+
+		dbPath, err := db.Path()
+		printPath("database", dbPath, err)
+
+		logPath, err := actions.CommandLogPath()
+		printPath("command log", logPath, err)
+
+		for _, dir := range config.DataDirs() {
+			printPath("data", dir, nil)
+		}
+
+		_ = w.Flush()
+	},
+}
+
+func init() {
+	configShowCmd.Flags().Bool("origin", false, "show which config layer each value came from")
+
+	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configPathsCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+// formats a config value for display,
+// lists, maps and commands are shown as JSON and strings are quoted
+func formatValue(value any) string {
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		// show empty lists and maps instead of null
+		if v.Len() == 0 && v.Kind() == reflect.Slice {
+			return "[]"
+		} else if v.Len() == 0 {
+			return "{}"
+		}
+
+		if data, err := json.Marshal(value); err == nil {
+			return string(data)
+		}
+	case reflect.Struct:
+		// an unset command
+		if v.IsZero() {
+			return "[]"
+		}
+
+		if data, err := json.Marshal(value); err == nil {
+			return string(data)
+		}
+	case reflect.String:
+		return fmt.Sprintf("%q", value)
+	}
+
+	return fmt.Sprint(value)
+}
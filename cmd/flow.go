@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/Bahaaio/pomo/config"
+	"github.com/Bahaaio/pomo/ui"
+	"github.com/spf13/cobra"
+)
+
+var flowCmd = &cobra.Command{
+	Use:   "flow",
+	Short: "Start an open-ended work session with a proportional break",
+	Long: `Start a Flowtime work session
+
+The work session counts up until you finish it, then the break is computed
+from the work time with flow.ratio, flow.minBreak and flow.maxBreak,
+or looked up in flow.breaks.`,
+	Example: `  pomo flow                   # Start a flow session
+  pomo flow -t "write report" # flow session with custom title (or --title)`,
+
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		applyConfig(cmd, config.WorkTask)
+
+		log.Println("starting flow session:", config.C.Work.Title)
+		runModel(ui.NewFlowModel(config.C), cmd, config.WorkTask)
+	},
+}
+
+func init() {
+	flowCmd.Flags().StringP(
+		"title",
+		"t",
+		"",
+		"work session title",
+	)
+
+	addCompactFlag(flowCmd)
+
+	rootCmd.AddCommand(flowCmd)
+}
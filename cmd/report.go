@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Bahaaio/pomo/db"
+	"github.com/Bahaaio/pomo/report"
+	"github.com/spf13/cobra"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report [date]",
+	Short: "Generate a weekly or monthly focus report",
+	Long: `Generate a focus report for the week or month containing the given date
+(YYYY-MM-DD, defaults to today). Weeks start on Monday.
+
+The report is printed to stdout as Markdown or HTML.`,
+	Example: `  pomo report                         # Report for the current week
+  pomo report --month                 # Report for the current month
+  pomo report --week 2025-01-06       # Report for the week containing that date
+  pomo report -m -f html > month.html # HTML report for the current month`,
+
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		period, date, format, err := parseReportArgs(cmd, args)
+		if err != nil {
+			_ = cmd.Usage()
+			die(err)
+		}
+
+		database, err := db.Connect()
+		if err != nil {
+			die(fmt.Errorf("could not connect to the database: %w", err))
+		}
+
+		r, err := report.Generate(db.NewSessionRepo(database), period, date)
+		if err != nil {
+			die(fmt.Errorf("could not generate report: %w", err))
+		}
+
+		output, err := r.Render(format)
+		if err != nil {
+			die(fmt.Errorf("could not render report: %w", err))
+		}
+
+		fmt.Print(output)
+	},
+}
+
+func init() {
+	reportCmd.Flags().BoolP("week", "w", false, "report for a week (default)")
+	reportCmd.Flags().BoolP("month", "m", false, "report for a month")
+	reportCmd.MarkFlagsMutuallyExclusive("week", "month")
+
+	reportCmd.Flags().StringP("format", "f", string(report.Markdown), "output format: markdown or html")
+
+	rootCmd.AddCommand(reportCmd)
+}
+
+// parses the report period, date, and output format
+func parseReportArgs(cmd *cobra.Command, args []string) (report.Period, time.Time, report.Format, error) {
+	period := report.Week
+	if month, _ := cmd.Flags().GetBool("month"); month {
+		period = report.Month
+	}
+
+	date := time.Now()
+	if len(args) > 0 {
+		var err error
+		date, err = time.ParseInLocation(db.DateFormat, args[0], time.Local)
+		if err != nil {
+			return 0, time.Time{}, "", fmt.Errorf("invalid date: '%v', expected YYYY-MM-DD", args[0])
+		}
+	}
+
+	formatName, _ := cmd.Flags().GetString("format")
+	format, err := report.ParseFormat(formatName)
+	if err != nil {
+		return 0, time.Time{}, "", err
+	}
+
+	return period, date, format, nil
+}
@@ -7,7 +7,9 @@ import (
 	"log"
 	"os"
 
+	"github.com/Bahaaio/pomo/actions"
 	"github.com/Bahaaio/pomo/config"
+	"github.com/Bahaaio/pomo/ui/colors"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/gen2brain/beeep"
 	"github.com/spf13/cobra"
@@ -27,11 +29,18 @@ desktop notifications when complete.`,
 	Example: `  pomo                   # Start work session
   pomo 1h15m             # Start 1 hour 15 minute session
   pomo 45m 15m           # Start 45 minute work session with 15 minute break
-  pomo -t "write report" # work session with custom title (or --title)`,
+  pomo -t "write report" # work session with custom title (or --title)
+  pomo -t "refactor" -e 3 # work on the task "refactor", estimated at 3 pomodoros
+  pomo --compact         # compact timer for a small pane (or -c)`,
 
 	Args: cobra.MaximumNArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		log.Println("rootCmd args:", args)
+
+		if err := estimateTask(cmd); err != nil {
+			die(err)
+		}
+
 		runTask(config.WorkTask, cmd)
 	},
 }
@@ -48,6 +57,15 @@ func init() {
 		"work session title",
 	)
 
+	addCompactFlag(rootCmd)
+
+	rootCmd.Flags().IntP(
+		"estimate",
+		"e",
+		0,
+		"estimated pomodoros of the task of the title",
+	)
+
 	initLogging()
 	initConfig()
 	beeep.AppName = config.AppName
@@ -60,6 +78,17 @@ func initConfig() {
 	if err := config.LoadConfig(); err != nil {
 		die(fmt.Errorf("could not load config: %w", err))
 	}
+
+	theme, err := config.C.Theme.Build()
+	if err != nil {
+		die(fmt.Errorf("invalid theme: %w", err))
+	}
+	colors.Current = theme
+
+	// action types are only known to the actions package
+	if _, err := actions.FromConfig(config.C); err != nil {
+		die(fmt.Errorf("invalid actions: %w", err))
+	}
 }
 
 func initLogging() {
@@ -78,6 +107,16 @@ func initLogging() {
 	log.SetFlags(log.Ltime)
 }
 
+// adds the flag of the inline display to a timer command
+func addCompactFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolP(
+		"compact",
+		"c",
+		false,
+		"compact timer in place of the full screen (display: inline)",
+	)
+}
+
 func die(err error) {
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
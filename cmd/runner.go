@@ -12,21 +12,43 @@ import (
 )
 
 func runTask(taskType config.TaskType, cmd *cobra.Command) {
-	task := taskType.GetTask()
+	applyConfig(cmd, taskType)
 
-	if err := parseArguments(cmd.Flags().Args(), task, &config.C.Break); err != nil {
+	log.Printf("starting %v session: %v", taskType.GetTask().Title, taskType.GetTask().Duration)
+	runModel(ui.NewModel(taskType, config.C), cmd, taskType)
+}
+
+// applies the command line overrides and key bindings of the config
+func applyConfig(cmd *cobra.Command, taskType config.TaskType) {
+	if err := applyOverrides(cmd, taskType, &config.C); err != nil {
 		_ = cmd.Usage()
 		die(err)
 	}
 
-	if err := parseFlags(cmd, &config.C.Work); err != nil {
-		die(err)
+	if err := ui.ApplyKeys(config.C.Keys); err != nil {
+		die(fmt.Errorf("invalid key bindings: %w", err))
 	}
+}
 
-	log.Printf("starting %v session: %v", taskType.GetTask().Title, taskType.GetTask().Duration)
+// runs the timer until it quits and prints the session summary
+func runModel(m ui.Model, cmd *cobra.Command, taskType config.TaskType) {
+	// the inline display renders in place, e.g. in a small tmux pane
+	var options []tea.ProgramOption
+	if config.C.Display != "inline" {
+		options = append(options, tea.WithAltScreen())
+	}
+
+	p := tea.NewProgram(m, options...)
 
-	m := ui.NewModel(taskType, config.C)
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	// apply config file changes to the running session
+	config.Watch(func() {
+		cfg, err := config.Reload()
+		if err == nil {
+			err = applyOverrides(cmd, taskType, &cfg)
+		}
+
+		p.Send(ui.ConfigReloadedMsg{Config: cfg, Err: err})
+	})
 
 	finalModel, err := p.Run()
 	if err != nil {
@@ -37,6 +59,19 @@ func runTask(taskType config.TaskType, cmd *cobra.Command) {
 	finalModel.(ui.Model).GetSessionSummary().Print()
 }
 
+// applies the command line arguments and flags on top of the given config
+func applyOverrides(cmd *cobra.Command, taskType config.TaskType, cfg *config.Config) error {
+	if err := parseArguments(cmd.Flags().Args(), taskType.From(cfg), &cfg.Break); err != nil {
+		return err
+	}
+
+	if compact, _ := cmd.Flags().GetBool("compact"); compact {
+		cfg.Display = "inline"
+	}
+
+	return parseFlags(cmd, &cfg.Work)
+}
+
 // parses the arguments and sets the duration
 // returns an error if the duration is invalid
 func parseArguments(args []string, task *config.Task, breakTask *config.Task) error {
@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"fmt"
+
+	"github.com/Bahaaio/pomo/config"
 	"github.com/Bahaaio/pomo/ui/stats"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -11,6 +14,10 @@ var statsCmd = &cobra.Command{
 	Args:  cobra.MaximumNArgs(0),
 	Short: "Display Pomodoro statistics and productivity metrics",
 	Run: func(cmd *cobra.Command, args []string) {
+		if err := stats.ApplyKeys(config.C.Keys.Stats); err != nil {
+			die(fmt.Errorf("invalid key bindings: %w", err))
+		}
+
 		m := stats.New()
 		p := tea.NewProgram(m, tea.WithAltScreen())
 
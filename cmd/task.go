@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Bahaaio/pomo/db"
+	"github.com/spf13/cobra"
+)
+
+var taskCmd = &cobra.Command{
+	Use:   "task",
+	Short: "Manage the task queue",
+	Long: `Manage the task queue
+
+Open tasks can be picked in the timer when a work session starts,
+the work sessions are linked to the picked task and counted as its pomodoros.`,
+	Example: `  pomo task add write report      # Add a task
+  pomo task add -e 3 write report # Add a task estimated at 3 pomodoros
+  pomo task list                  # List the open tasks
+  pomo task done 2                # Mark task 2 as done`,
+}
+
+var taskAddCmd = &cobra.Command{
+	Use:   "add <title>",
+	Short: "Add a task to the queue",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		title := strings.TrimSpace(strings.Join(args, " "))
+		if title == "" {
+			_ = cmd.Usage()
+			die(errors.New("the task title can't be empty"))
+		}
+
+		estimate, err := parseEstimate(cmd)
+		if err != nil {
+			_ = cmd.Usage()
+			die(err)
+		}
+
+		task, err := newTaskRepo().CreateTask(title, estimate)
+		if err != nil {
+			die(fmt.Errorf("could not add the task: %w", err))
+		}
+
+		fmt.Printf("added task %d: %s\n", task.ID, task.Title)
+	},
+}
+
+var taskListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the open tasks with their pomodoros",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		repo := newTaskRepo()
+
+		tasks, err := repo.GetOpenTasks()
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			tasks, err = repo.GetTasks()
+		}
+		if err != nil {
+			die(fmt.Errorf("could not list the tasks: %w", err))
+		}
+
+		if len(tasks) == 0 {
+			fmt.Println("no tasks, add one with: pomo task add <title>")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, task := range tasks {
+			status := " "
+			if task.Done() {
+				status = "✓"
+			}
+
+			pomodoros := ""
+			if task.Estimate > 0 {
+				pomodoros = fmt.Sprintf("🍅 %d/%d", task.Pomodoros, task.Estimate)
+			} else if task.Pomodoros > 0 {
+				pomodoros = fmt.Sprintf("🍅×%d", task.Pomodoros)
+			}
+
+			fmt.Fprintf(w, "%d\t%s %s\t%s\n", task.ID, status, task.Title, pomodoros)
+		}
+		_ = w.Flush()
+	},
+}
+
+var taskDoneCmd = &cobra.Command{
+	Use:   "done <id>...",
+	Short: "Mark tasks as done",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ids := make([]int, 0, len(args))
+		for _, arg := range args {
+			id, err := strconv.Atoi(arg)
+			if err != nil {
+				_ = cmd.Usage()
+				die(fmt.Errorf("invalid task id: '%v'", arg))
+			}
+			ids = append(ids, id)
+		}
+
+		// every task is tried, the failures are reported together
+		var errs []error
+
+		repo := newTaskRepo()
+		for _, id := range ids {
+			if err := repo.CompleteTask(id); err != nil {
+				if errors.Is(err, db.ErrTaskNotFound) {
+					errs = append(errs, fmt.Errorf("no open task with id %d", id))
+				} else {
+					errs = append(errs, fmt.Errorf("could not complete task %d: %w", id, err))
+				}
+				continue
+			}
+
+			fmt.Printf("task %d done\n", id)
+		}
+
+		if len(errs) > 0 {
+			die(errors.Join(errs...))
+		}
+	},
+}
+
+func init() {
+	taskAddCmd.Flags().IntP("estimate", "e", 0, "estimated pomodoros of the task")
+	taskListCmd.Flags().BoolP("all", "a", false, "include the done tasks")
+
+	taskCmd.AddCommand(taskAddCmd, taskListCmd, taskDoneCmd)
+	rootCmd.AddCommand(taskCmd)
+}
+
+// records the estimate flag of a work session for the task of its title,
+// adding the task to the queue if it's not there yet
+func estimateTask(cmd *cobra.Command) error {
+	estimate, err := parseEstimate(cmd)
+	if err != nil || estimate == 0 {
+		return err
+	}
+
+	title, _ := cmd.Flags().GetString("title")
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return errors.New("an estimate needs the title of the task, e.g. -t \"refactor parser\" --estimate 3")
+	}
+
+	repo := newTaskRepo()
+
+	tasks, err := repo.GetOpenTasks()
+	if err != nil {
+		return fmt.Errorf("could not get the tasks: %w", err)
+	}
+
+	for _, task := range tasks {
+		if task.Title == title {
+			log.Printf("estimating task %d %q at %d pomodoros", task.ID, title, estimate)
+			if err := repo.SetEstimate(task.ID, estimate); err != nil {
+				return fmt.Errorf("could not set the estimate: %w", err)
+			}
+			return nil
+		}
+	}
+
+	log.Printf("adding task %q estimated at %d pomodoros", title, estimate)
+	if _, err := repo.CreateTask(title, estimate); err != nil {
+		return fmt.Errorf("could not add the task: %w", err)
+	}
+
+	return nil
+}
+
+// parses the estimate flag, zero if it's not set
+func parseEstimate(cmd *cobra.Command) (int, error) {
+	estimate, _ := cmd.Flags().GetInt("estimate")
+	if estimate < 0 {
+		return 0, fmt.Errorf("invalid estimate: '%v'", estimate)
+	}
+
+	return estimate, nil
+}
+
+// connects to the database of the task queue
+func newTaskRepo() *db.TaskRepo {
+	database, err := db.Connect()
+	if err != nil {
+		die(fmt.Errorf("could not connect to the database: %w", err))
+	}
+
+	return db.NewTaskRepo(database)
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// Events are the session events actions, hooks and webhooks run on.
+var Events = []string{
+	"start", "pause", "resume", "skip", "quit",
+	"complete", "longBreak", "cycleComplete",
+}
+
+// Action is an entry of the actions list,
+// it's instantiated by the action type registered under Type.
+type Action struct {
+	Type    string
+	Name    string         // shown in logs and failures, defaults to the type
+	Events  []string       // events to run on, all if empty
+	Options map[string]any // decoded by the action type
+}
+
+// Wants reports whether the action should run on the event.
+func (a Action) Wants(event string) bool {
+	return MatchEvent(a.Events, event)
+}
+
+// DisplayName returns the name of the action, or its type if it has none.
+func (a Action) DisplayName() string {
+	if a.Name != "" {
+		return a.Name
+	}
+
+	return a.Type
+}
+
+// DecodeOptions decodes the options into target like the rest of the config,
+// e.g. durations from strings and commands from lists.
+func (a Action) DecodeOptions(target any) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       decodeHook,
+		ErrorUnused:      true,
+		WeaklyTypedInput: true,
+		Result:           target,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(a.Options); err != nil {
+		return fmt.Errorf("invalid options of %v action: %w", a.DisplayName(), err)
+	}
+
+	return nil
+}
+
+func (a Action) validate() error {
+	if a.Type == "" {
+		return fmt.Errorf("action type is empty")
+	}
+
+	return validateEvents(a.Events)
+}
+
+// MatchEvent reports whether the event is one of events, case-insensitively.
+// an empty list matches every event.
+func MatchEvent(events []string, event string) bool {
+	return len(events) == 0 || slices.ContainsFunc(events, func(e string) bool {
+		return strings.EqualFold(e, event)
+	})
+}
+
+func validateEvents(events []string) error {
+	for _, event := range events {
+		if !slices.ContainsFunc(Events, func(e string) bool { return strings.EqualFold(e, event) }) {
+			return fmt.Errorf("unknown event '%v', expected one of: %v", event, strings.Join(Events, ", "))
+		}
+	}
+
+	return nil
+}
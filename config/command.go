@@ -0,0 +1,144 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Command is a post command or hook.
+// in the config it's either a list of arguments, e.g. [notify-send, done],
+// or an object with the arguments under command and execution options.
+type Command struct {
+	Args []string `mapstructure:"command" json:"command"`
+
+	// defaults to 5s for foreground commands, background commands have no default
+	Timeout time.Duration `json:"timeout,omitempty"`
+
+	// runs the arguments joined with spaces through sh -c (cmd /C on Windows)
+	Shell bool `json:"shell,omitempty"`
+
+	// runs the command detached, it isn't waited for and can outlive pomo
+	Background bool `json:"background,omitempty"`
+
+	Workdir string            `json:"workdir,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+
+	// keeps running the next commands if this one fails,
+	// the list form always continues
+	ContinueOnError bool `json:"continueOnError,omitempty"`
+}
+
+// MarshalJSON writes commands without options in the list form.
+func (c Command) MarshalJSON() ([]byte, error) {
+	if c.isPlain() {
+		return json.Marshal(c.Args)
+	}
+
+	type command Command // without this method
+	return json.Marshal(command(c))
+}
+
+// reports whether the command has the options of the list form
+func (c Command) isPlain() bool {
+	return c.ContinueOnError &&
+		c.Timeout == 0 && !c.Shell && !c.Background &&
+		c.Workdir == "" && len(c.Env) == 0
+}
+
+func (c Command) validate() error {
+	if len(c.Args) == 0 {
+		return fmt.Errorf("command is empty")
+	}
+
+	if c.Timeout < 0 {
+		return fmt.Errorf("invalid timeout '%v' for %q", c.Timeout, c.Args)
+	}
+
+	return nil
+}
+
+var commandType = reflect.TypeFor[Command]()
+
+// decodes the list form of a command, e.g. [notify-send, done],
+// and lets the command option be a single string, e.g. for shell scripts
+func commandHook(from, to reflect.Type, data any) (any, error) {
+	if to != commandType {
+		return data, nil
+	}
+
+	switch from.Kind() {
+	case reflect.Slice:
+		return map[string]any{"command": data, "continueOnError": true}, nil
+
+	case reflect.Map:
+		options, ok := data.(map[string]any)
+		if !ok {
+			return data, nil
+		}
+
+		if script, isString := options["command"].(string); isString {
+			options["command"] = []string{script}
+		}
+		return options, nil
+
+	default:
+		return data, nil
+	}
+}
+
+// checks every command of the list
+func validateCommands(name string, commands []Command) error {
+	for i, command := range commands {
+		if err := command.validate(); err != nil {
+			return fmt.Errorf("invalid %v command %d: %w", name, i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// ExpandCommand expands tilde in the arguments and working directory of the command
+// like the commands of the config, for commands decoded from action options.
+func ExpandCommand(cmd Command) Command {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return cmd
+	}
+
+	return expandCommands([]Command{cmd}, homeDir)[0]
+}
+
+// expands tilde in command arguments and working directories to the user's home directory.
+// environment variable names are uppercased, viper lowercases map keys
+func expandCommands(commands []Command, homeDir string) []Command {
+	if len(commands) == 0 {
+		return commands
+	}
+
+	expanded := make([]Command, len(commands))
+
+	for i, cmd := range commands {
+		args := make([]string, len(cmd.Args))
+		for j, arg := range cmd.Args {
+			args[j] = expandPath(arg, homeDir)
+		}
+
+		cmd.Args = args
+		cmd.Workdir = expandPath(cmd.Workdir, homeDir)
+
+		if len(cmd.Env) > 0 {
+			env := make(map[string]string, len(cmd.Env))
+			for key, value := range cmd.Env {
+				env[strings.ToUpper(key)] = value
+			}
+			cmd.Env = env
+		}
+		expanded[i] = cmd
+	}
+
+	return expanded
+}
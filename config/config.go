@@ -4,17 +4,20 @@ package config
 
 import (
 	_ "embed"
-	"errors"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"strings"
 	"time"
 
 	"github.com/Bahaaio/pomo/ui/ascii"
 	"github.com/Bahaaio/pomo/ui/colors"
+	"github.com/Bahaaio/pomo/xdg"
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/spf13/viper"
 )
 
@@ -34,14 +37,19 @@ type Notification struct {
 type Task struct {
 	Title        string
 	Duration     time.Duration
-	Then         [][]string
+	Then         []Command
 	Notification Notification
 }
 
+// LongBreak is the break task started after every After work sessions,
+// unset title, notification and then values fall back to the break task.
 type LongBreak struct {
-	Enabled  bool
-	After    int
-	Duration time.Duration
+	Enabled bool
+	After   int
+	Task    `mapstructure:",squash"`
+
+	// color of the ASCII art timer during long breaks, defaults to asciiArt.color
+	Color string
 }
 
 type ASCIIArt struct {
@@ -50,12 +58,59 @@ type ASCIIArt struct {
 	Color   string
 }
 
+// Hooks are commands run on session lifecycle events,
+// in the same format as [Task.Then]
+type Hooks struct {
+	OnStart         []Command // a session starts
+	OnPause         []Command
+	OnResume        []Command
+	OnSkip          []Command // a session is skipped before it ends
+	OnQuit          []Command
+	OnLongBreak     []Command // a long break starts
+	OnCycleComplete []Command // a long break ends
+}
+
+// Keys remaps key bindings of each screen,
+// keyed by action name, e.g. timer.pause: [p, space]
+type Keys struct {
+	Timer   map[string][]string
+	Confirm map[string][]string
+	Stats   map[string][]string
+}
+
+// Timer holds the settings of the timer controls.
+type Timer struct {
+	Step time.Duration // added or removed by the increase and decrease keys
+
+	// keep counting past zero until the session is finished,
+	// the extra time is recorded with the session
+	Overtime bool
+}
+
+// Theme is a built-in color theme with optional per-color overrides,
+// e.g. colors.border: "#268BD2"
+type Theme struct {
+	Name   string
+	Colors map[string]string
+}
+
 type Config struct {
-	OnSessionEnd string
-	ASCIIArt     ASCIIArt
-	Work         Task
-	Break        Task
-	LongBreak    LongBreak
+	OnSessionEnd  string
+	Display       string // full screen, or inline for a compact timer in place
+	Theme         Theme
+	ASCIIArt      ASCIIArt
+	Timer         Timer
+	Work          Task
+	Break         Task
+	LongBreak     LongBreak
+	Flow          Flow
+	DailyGoal     int // work sessions per day, 0 for no goal
+	Hooks         Hooks
+	Webhooks      []Webhook
+	Actions       []Action
+	Notifications Notifications
+	Reminder      Reminder
+	Keys          Keys
 }
 
 var (
@@ -65,10 +120,18 @@ var (
 
 	DefaultConfig = map[string]any{
 		"onSessionEnd": "ask",
+		"display":      "full",
+		"theme": map[string]any{
+			"name": colors.DefaultTheme,
+		},
 		"asciiArt": map[string]any{
 			"enabled": true,
 			"font":    ascii.DefaultFont,
-			"color":   colors.TimerFg,
+			"color":   "", // the theme's timer color
+		},
+		"timer": map[string]any{
+			"step":     time.Minute,
+			"overtime": false,
 		},
 		"work": map[string]any{
 			"duration": 25 * time.Minute,
@@ -95,56 +158,204 @@ var (
 			"after":    4,
 			"duration": 15 * time.Minute,
 		},
+		"flow": map[string]any{
+			"ratio":    0.2, // 1/5
+			"minBreak": 2 * time.Minute,
+			"maxBreak": 30 * time.Minute,
+		},
+		"dailyGoal": 0,
+		"notifications": map[string]any{
+			"backends": []string{"desktop"},
+		},
+		"reminder": map[string]any{
+			"interval":    0,
+			"urgentAfter": 3,
+			"title":       "still there? 👀",
+			"message":     "{{.Title}} ended {{duration .Idle}} ago",
+		},
 	}
 )
 
 func Setup() {
-	if configFile, err := getConfigFile(); err == nil {
-		log.Println("using config file:", configFile)
-		viper.SetConfigFile(configFile)
-	} else {
-		log.Println("could not get user config dir:", err)
+	migrateUserConfig()
+
+	layers = findLayers()
+	for _, layer := range layers {
+		log.Println("using config file:", layer)
 	}
 
 	log.Println("setting default config values")
 	setDefaults()
+
+	log.Println("binding environment variables")
+	bindEnv()
 }
 
 func LoadConfig() error {
-	log.Println("loading config")
+	cfg, err := readConfig()
+	if err != nil {
+		return err
+	}
+
+	C = cfg
+	return nil
+}
+
+// Reload re-reads the config file and returns the new config,
+// C is left untouched so the caller can decide when to apply it.
+func Reload() (Config, error) {
+	log.Println("reloading config")
+	return readConfig()
+}
 
-	// fall back to defaults if no config file is found
-	if err := viper.ReadInConfig(); err != nil {
-		log.Println("no config file found, using defaults:", err)
-	} else {
-		log.Println("read config:", viper.ConfigFileUsed())
+// Validate checks the config for values that can't be used.
+func (c Config) Validate() error {
+	switch c.OnSessionEnd {
+	case "ask", "start", "quit":
+	default:
+		return fmt.Errorf("invalid onSessionEnd: '%v', expected ask, start or quit", c.OnSessionEnd)
 	}
 
-	err := viper.Unmarshal(&C)
-	if err != nil {
+	switch c.Display {
+	case "full", "inline":
+	default:
+		return fmt.Errorf("invalid display: '%v', expected full or inline", c.Display)
+	}
+
+	if c.Timer.Step <= 0 {
+		return fmt.Errorf("invalid timer step: '%v'", c.Timer.Step)
+	}
+
+	if c.Work.Duration <= 0 {
+		return fmt.Errorf("invalid work duration: '%v'", c.Work.Duration)
+	}
+
+	if c.Break.Duration <= 0 {
+		return fmt.Errorf("invalid break duration: '%v'", c.Break.Duration)
+	}
+
+	if c.LongBreak.Enabled && c.LongBreak.Duration <= 0 {
+		return fmt.Errorf("invalid long break duration: '%v'", c.LongBreak.Duration)
+	}
+
+	if err := c.Flow.validate(); err != nil {
+		return err
+	}
+
+	if c.DailyGoal < 0 {
+		return fmt.Errorf("invalid daily goal: '%v'", c.DailyGoal)
+	}
+
+	for name, task := range map[string]Task{"work": c.Work, "break": c.Break, "longBreak": c.LongBreak.Task} {
+		if err := validateCommands(name+".then", task.Then); err != nil {
+			return err
+		}
+	}
+
+	for name, hook := range c.Hooks.all() {
+		if err := validateCommands("hooks."+name, *hook); err != nil {
+			return err
+		}
+	}
+
+	for i, webhook := range c.Webhooks {
+		if err := webhook.Validate(); err != nil {
+			return fmt.Errorf("invalid webhook %d: %w", i+1, err)
+		}
+	}
+
+	for i, action := range c.Actions {
+		if err := action.validate(); err != nil {
+			return fmt.Errorf("invalid action %d: %w", i+1, err)
+		}
+	}
+
+	if err := c.Notifications.validate(); err != nil {
+		return err
+	}
+
+	if err := c.Reminder.validate(); err != nil {
 		return err
 	}
-	log.Println("Unmarshaled config:", C)
 
-	if C.LongBreak.After <= 0 {
-		log.Printf("invalid long break steps %d, defaulting to 4", C.LongBreak.After)
-		C.LongBreak.After = 4
+	if _, err := c.Theme.Build(); err != nil {
+		return fmt.Errorf("invalid theme: %w", err)
+	}
+
+	return nil
+}
+
+// reads, validates, and normalizes the config
+func readConfig() (Config, error) {
+	log.Println("loading config")
+
+	// falls back to defaults if no config file is found
+	if err := readLayers(); err != nil {
+		return Config{}, err
+	}
+
+	var cfg Config
+	if err := viper.Unmarshal(&cfg, viper.DecodeHook(decodeHook)); err != nil {
+		return Config{}, err
+	}
+	log.Println("Unmarshaled config:", cfg)
+
+	if cfg.LongBreak.After <= 0 {
+		log.Printf("invalid long break steps %d, defaulting to 4", cfg.LongBreak.After)
+		cfg.LongBreak.After = 4
+	}
+
+	inheritBreak(&cfg.LongBreak, cfg.Break, viper.IsSet)
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
 	}
 
 	homedir, err := os.UserHomeDir()
 	if err != nil {
-		return fmt.Errorf("could not get user home directory: %w; please ensure $HOME is set correctly", err)
+		return Config{}, fmt.Errorf("could not get user home directory: %w; please ensure $HOME is set correctly", err)
 	}
 
-	// expand notification icon paths
-	C.Work.Notification.Icon = expandPath(C.Work.Notification.Icon, homedir)
-	C.Break.Notification.Icon = expandPath(C.Break.Notification.Icon, homedir)
+	// expand notification icon paths, file names are looked up in the data directories
+	cfg.Work.Notification.Icon = findDataFile(expandPath(cfg.Work.Notification.Icon, homedir))
+	cfg.Break.Notification.Icon = findDataFile(expandPath(cfg.Break.Notification.Icon, homedir))
+	cfg.LongBreak.Notification.Icon = findDataFile(expandPath(cfg.LongBreak.Notification.Icon, homedir))
 
 	// expand post command paths
-	C.Work.Then = expandCommands(C.Work.Then, homedir)
-	C.Break.Then = expandCommands(C.Break.Then, homedir)
+	cfg.Work.Then = expandCommands(cfg.Work.Then, homedir)
+	cfg.Break.Then = expandCommands(cfg.Break.Then, homedir)
+	cfg.LongBreak.Then = expandCommands(cfg.LongBreak.Then, homedir)
+	cfg.Hooks.expand(homedir)
+	if len(cfg.Notifications.Command.Args) > 0 {
+		cfg.Notifications.Command = expandCommands([]Command{cfg.Notifications.Command}, homedir)[0]
+	}
 
-	return nil
+	return cfg, nil
+}
+
+// Build returns the colors of the theme with its overrides applied.
+func (t Theme) Build() (colors.Theme, error) {
+	return colors.NewTheme(t.Name, t.Colors)
+}
+
+// fills the long break values that aren't set in any config layer or env with the break ones,
+// the title falls back to "long " + the break title
+func inheritBreak(longBreak *LongBreak, breakTask Task, isSet func(key string) bool) {
+	inherit := func(key string, apply func()) {
+		if !isSet("longBreak." + key) {
+			apply()
+		}
+	}
+
+	inherit("title", func() { longBreak.Title = "long " + breakTask.Title })
+	inherit("then", func() { longBreak.Then = breakTask.Then })
+
+	notification := &longBreak.Notification
+	inherit("notification.enabled", func() { notification.Enabled = breakTask.Notification.Enabled })
+	inherit("notification.urgent", func() { notification.Urgent = breakTask.Notification.Urgent })
+	inherit("notification.title", func() { notification.Title = breakTask.Notification.Title })
+	inherit("notification.message", func() { notification.Message = breakTask.Notification.Message })
+	inherit("notification.icon", func() { notification.Icon = breakTask.Notification.Icon })
 }
 
 func setDefaults() {
@@ -153,69 +364,167 @@ func setDefaults() {
 	}
 }
 
-// returns the path to the config file if it exists
-func getConfigFile() (string, error) {
-	var err error
+// binds every config key to a POMO_ prefixed environment variable,
+// e.g. work.duration -> POMO_WORK_DURATION
+//
+// environment variables take precedence over the config file.
+func bindEnv() {
+	viper.SetEnvPrefix(AppName)
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+
+	for _, key := range configKeys() {
+		if err := viper.BindEnv(key); err != nil {
+			log.Printf("failed to bind env for %q: %v", key, err)
+		}
+	}
+}
+
+// decodes durations, comma separated lists, commands, ratios, and JSON for nested lists, lists of objects and maps,
+// the latter lets `then`, `webhooks`, `actions`, `keys` and commands be set from environment variables
+var decodeHook = mapstructure.ComposeDecodeHookFunc(
+	stringToJSONHook,
+	commandHook,
+	ratioHook,
+	mapstructure.StringToTimeDurationHookFunc(),
+	mapstructure.StringToSliceHookFunc(","),
+)
 
-	// check current directory
-	if _, err = os.Stat(ConfigFile); err == nil {
-		return ConfigFile, nil
+func stringToJSONHook(from, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String {
+		return data, nil
 	}
 
-	// check config directory
-	var configDir string
-	if configDir, err = getConfigDir(); err == nil {
-		configPath := filepath.Join(configDir, ConfigFile)
+	isNestedList := to.Kind() == reflect.Slice &&
+		(to.Elem().Kind() == reflect.Slice || to.Elem().Kind() == reflect.Struct)
+	if !isNestedList && to.Kind() != reflect.Map && to != commandType {
+		return data, nil
+	}
 
-		if _, err := os.Stat(configPath); err == nil {
-			return configPath, nil
-		}
+	// decoded into the target type by the other hooks
+	var value any
+	if err := json.Unmarshal([]byte(data.(string)), &value); err != nil {
+		return nil, fmt.Errorf("invalid value %q, expected JSON: %w", data, err)
 	}
 
-	return "", fmt.Errorf("config file not found: %w", err)
+	return value, nil
 }
 
 // returns the config directory for the app
 func getConfigDir() (string, error) {
-	var dir string
+	dir, err := xdg.ConfigHome()
+	if err != nil {
+		return "", err
+	}
 
-	// on linux and macOS, use ~/.config
-	if runtime.GOOS == "linux" || runtime.GOOS == "darwin" {
-		dir = os.Getenv("HOME")
-		if dir == "" {
-			return "", errors.New("$HOME is not defined")
-		}
+	return filepath.Join(dir, AppName), nil
+}
 
-		dir = filepath.Join(dir, ".config")
-	} else {
-		// on other OSes, use the standard user config directory
-		var err error
-		dir, err = os.UserConfigDir()
-		if err != nil {
-			return "", err
-		}
+// UserConfigFile returns the path of the user config file, whether it exists or not.
+func UserConfigFile() (string, error) {
+	dir, err := getConfigDir()
+	if err != nil {
+		return "", err
 	}
 
-	return filepath.Join(dir, AppName), nil
+	return filepath.Join(dir, ConfigFile), nil
+}
+
+// SystemConfigFiles returns the candidate system-wide config files, most important first.
+// only the first one that exists is used.
+func SystemConfigFiles() []string {
+	var files []string
+
+	for _, dir := range xdg.ConfigDirs() {
+		files = append(files, filepath.Join(dir, AppName, ConfigFile))
+	}
+
+	// not part of the XDG spec, but a common place for system config
+	if runtime.GOOS != "windows" {
+		files = append(files, filepath.Join("/etc", AppName, ConfigFile))
+	}
+
+	return files
 }
 
-// expands tilde in command arguments to the user's home directory
-func expandCommands(commands [][]string, homeDir string) [][]string {
-	if len(commands) == 0 || commands == nil {
-		return commands
+// DataDirs returns the directories of the app data, e.g. notification icons, most important first:
+// the user one in $XDG_DATA_HOME, then the system-wide ones in $XDG_DATA_DIRS.
+func DataDirs() []string {
+	var dirs []string
+
+	if dir, err := xdg.DataHome(); err == nil {
+		dirs = append(dirs, filepath.Join(dir, AppName))
+	}
+
+	for _, dir := range xdg.DataDirs() {
+		dirs = append(dirs, filepath.Join(dir, AppName))
 	}
 
-	expanded := make([][]string, len(commands))
+	return dirs
+}
 
-	for i, cmd := range commands {
-		expanded[i] = make([]string, len(cmd))
+// returns the path of a file name found in the data directories,
+// paths with a directory and files that aren't found are returned as is
+func findDataFile(name string) string {
+	if name == "" || filepath.Base(name) != name {
+		return name
+	}
 
-		for j, arg := range cmd {
-			expanded[i][j] = expandPath(arg, homeDir)
+	for _, dir := range DataDirs() {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
 		}
 	}
 
-	return expanded
+	return name
+}
+
+// moves the user config file from ~/.config to $XDG_CONFIG_HOME if needed
+func migrateUserConfig() {
+	legacyDir, err := xdg.DefaultConfigHome()
+	if err != nil {
+		return
+	}
+
+	configFile, err := UserConfigFile()
+	if err != nil {
+		return
+	}
+
+	legacyFile := filepath.Join(legacyDir, AppName, ConfigFile)
+	if _, err := xdg.Migrate(legacyFile, configFile); err != nil {
+		log.Println("failed to migrate config file:", err)
+	}
+}
+
+// returns the commands of every hook by name
+func (h *Hooks) all() map[string]*[]Command {
+	return map[string]*[]Command{
+		"onStart":         &h.OnStart,
+		"onPause":         &h.OnPause,
+		"onResume":        &h.OnResume,
+		"onSkip":          &h.OnSkip,
+		"onQuit":          &h.OnQuit,
+		"onLongBreak":     &h.OnLongBreak,
+		"onCycleComplete": &h.OnCycleComplete,
+	}
+}
+
+// ByName returns the commands of every hook by name, e.g. onStart.
+func (h Hooks) ByName() map[string][]Command {
+	hooks := make(map[string][]Command)
+	for name, hook := range h.all() {
+		hooks[name] = *hook
+	}
+
+	return hooks
+}
+
+// expands the paths of every hook command
+func (h *Hooks) expand(homeDir string) {
+	for _, hook := range h.all() {
+		*hook = expandCommands(*hook, homeDir)
+	}
 }
 
 // expands tilde to the user's home directory
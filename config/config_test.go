@@ -1,15 +1,21 @@
 package config
 
 import (
+	"encoding/json"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/Bahaaio/pomo/ui/colors"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var homeDir string
@@ -62,6 +68,9 @@ asciiArt:
   color: "#FF0000"
 longBreak:
   after: 3
+timer:
+  step: 5m
+  overtime: true
 `
 
 	setupViper()
@@ -73,6 +82,8 @@ longBreak:
 	assert.Equal(t, "custom work", C.Work.Title, "Work title should be 'custom work'")
 	assert.Equal(t, "#FF0000", C.ASCIIArt.Color, "ASCII art color should be '#FF0000'")
 	assert.Equal(t, 3, C.LongBreak.After, "Long break should be after 3 sessions")
+	assert.Equal(t, 5*time.Minute, C.Timer.Step, "Timer step should be 5 minutes")
+	assert.True(t, C.Timer.Overtime, "Overtime should be enabled")
 
 	defaults := getDefaultConfig()
 
@@ -102,12 +113,13 @@ work:
 		{"osascript", "-e", "display notification \"Break time!\""},
 		{"python", homeDir + "/scripts/work-done.py"},
 	}
-	assert.Equal(t, expectedThen, C.Work.Then, "Work then commands should match")
+	assert.Equal(t, plainCommands(expectedThen...), C.Work.Then, "Work then commands should match")
 }
 
 func TestLoadConfigAllFieldsComprehensive(t *testing.T) {
 	configYAML := `
 onSessionEnd: start
+display: inline
 asciiArt:
   enabled: true
   font: ansi
@@ -146,6 +158,7 @@ longBreak:
 
 	// main config
 	assert.Equal(t, "start", C.OnSessionEnd, "OnSessionEnd should be 'start'")
+	assert.Equal(t, "inline", C.Display, "Display should be 'inline'")
 
 	// ASCII art
 	assert.True(t, C.ASCIIArt.Enabled, "ASCII art should be enabled")
@@ -156,7 +169,7 @@ longBreak:
 	assert.Equal(t, 45*time.Minute, C.Work.Duration, "Work duration should be 45 minutes")
 	assert.Equal(t, "Deep work session", C.Work.Title, "Work title should match")
 	expectedWorkThen := [][]string{{"echo", "work completed"}, {"notify-send", "Break time!"}}
-	assert.Equal(t, expectedWorkThen, C.Work.Then, "Work then commands should match")
+	assert.Equal(t, plainCommands(expectedWorkThen...), C.Work.Then, "Work then commands should match")
 
 	// work notification
 	assert.True(t, C.Work.Notification.Enabled, "Work notification should be enabled")
@@ -169,7 +182,7 @@ longBreak:
 	assert.Equal(t, 15*time.Minute, C.Break.Duration, "Break duration should be 15 minutes")
 	assert.Equal(t, "Relaxation break", C.Break.Title, "Break title should match")
 	expectedBreakThen := [][]string{{"echo", "break finished"}}
-	assert.Equal(t, expectedBreakThen, C.Break.Then, "Break then commands should match")
+	assert.Equal(t, plainCommands(expectedBreakThen...), C.Break.Then, "Break then commands should match")
 
 	// break notification
 	assert.False(t, C.Break.Notification.Enabled, "Break notification should be disabled")
@@ -184,6 +197,622 @@ longBreak:
 	assert.Equal(t, 16*time.Minute, C.LongBreak.Duration, "Long break duration should be 16 minutes")
 }
 
+func TestLoadConfigLongBreak(t *testing.T) {
+	configYAML := `
+break:
+  title: rest
+  notification:
+    urgent: true
+    title: break over
+    message: back to work!
+  then:
+    - [echo, break]
+longBreak:
+  duration: 30m
+  color: "#00FF00"
+  notification:
+    message: stretch your legs
+    enabled: false
+  then:
+    - [loginctl, lock-session]
+`
+
+	setupViper()
+	writeAndLoadConfig(t, configYAML)
+
+	longBreak := C.LongBreak
+	assert.Equal(t, 30*time.Minute, longBreak.Duration)
+	assert.Equal(t, "#00FF00", longBreak.Color)
+	assert.Equal(t, "long rest", longBreak.Title, "title should fall back to the break title")
+	assert.Equal(t, plainCommands([]string{"loginctl", "lock-session"}), longBreak.Then)
+
+	// notification fields fall back one by one
+	assert.False(t, longBreak.Notification.Enabled)
+	assert.True(t, longBreak.Notification.Urgent)
+	assert.Equal(t, "break over", longBreak.Notification.Title)
+	assert.Equal(t, "stretch your legs", longBreak.Notification.Message)
+
+	t.Setenv("POMO_LONGBREAK_TITLE", "nap")
+	assert.NoError(t, LoadConfig())
+	assert.Equal(t, "nap", C.LongBreak.Title, "env values should not be overridden by the break ones")
+}
+
+func TestLoadConfigHooks(t *testing.T) {
+	configYAML := `
+hooks:
+  onStart:
+    - [dnd, "on"]
+  onQuit:
+    - [dnd, "off"]
+    - [~/bin/slack-status, clear]
+`
+
+	t.Setenv("POMO_HOOKS_ONPAUSE", `[["playerctl", "pause"]]`)
+
+	setupViper()
+	writeAndLoadConfig(t, configYAML)
+
+	assert.Equal(t, plainCommands([]string{"dnd", "on"}), C.Hooks.OnStart)
+	assert.Equal(t, plainCommands([]string{"dnd", "off"}, []string{homeDir + "/bin/slack-status", "clear"}), C.Hooks.OnQuit, "hook paths should be expanded")
+	assert.Equal(t, plainCommands([]string{"playerctl", "pause"}), C.Hooks.OnPause)
+	assert.Empty(t, C.Hooks.OnResume)
+}
+
+func TestLoadConfigCommandOptions(t *testing.T) {
+	configYAML := `
+work:
+  then:
+    - [notify-send, done]
+    - command: [~/bin/sync-notes, --all]
+      timeout: 30s
+      workdir: ~/notes
+      env:
+        NOTES_REMOTE: origin
+      continueOnError: true
+    - command: echo "$POMO_TITLE" >> ~/pomo.log
+      shell: true
+      background: true
+`
+
+	t.Setenv("POMO_BREAK_THEN", `[["echo", "break"], {"command": ["spd-say", "back"], "timeout": "10s"}]`)
+
+	setupViper()
+	writeAndLoadConfig(t, configYAML)
+
+	assert.Equal(t, []Command{
+		{Args: []string{"notify-send", "done"}, ContinueOnError: true},
+		{
+			Args:            []string{homeDir + "/bin/sync-notes", "--all"},
+			Timeout:         30 * time.Second,
+			Workdir:         homeDir + "/notes",
+			Env:             map[string]string{"NOTES_REMOTE": "origin"},
+			ContinueOnError: true,
+		},
+		{Args: []string{`echo "$POMO_TITLE" >> ~/pomo.log`}, Shell: true, Background: true},
+	}, C.Work.Then)
+
+	assert.Equal(t, []Command{
+		{Args: []string{"echo", "break"}, ContinueOnError: true},
+		{Args: []string{"spd-say", "back"}, Timeout: 10 * time.Second},
+	}, C.Break.Then, "env commands should support both forms")
+}
+
+func TestLoadConfigWebhooks(t *testing.T) {
+	configYAML := `
+webhooks:
+  - url: https://dashboard.example.com/pomo
+    events: [complete, cycleComplete]
+    headers:
+      Authorization: Bearer $DASHBOARD_TOKEN
+    timeout: 2s
+    retries: 3
+    backoff: 500ms
+`
+
+	setupViper()
+	writeAndLoadConfig(t, configYAML)
+
+	require.Len(t, C.Webhooks, 1)
+	webhook := C.Webhooks[0]
+
+	assert.Equal(t, "https://dashboard.example.com/pomo", webhook.URL)
+	assert.Equal(t, 2*time.Second, webhook.Timeout)
+	assert.Equal(t, 3, webhook.Retries)
+	assert.Equal(t, 500*time.Millisecond, webhook.Backoff)
+	assert.Equal(t, "Bearer $DASHBOARD_TOKEN", webhook.Headers["authorization"])
+
+	assert.True(t, webhook.Wants("complete"))
+	assert.True(t, webhook.Wants("cyclecomplete"), "events are case-insensitive")
+	assert.False(t, webhook.Wants("start"))
+	assert.True(t, Webhook{}.Wants("start"), "webhooks without events want all of them")
+
+	t.Setenv("POMO_WEBHOOKS", `[{"url": "http://localhost:8080/hook"}]`)
+	assert.NoError(t, LoadConfig())
+	assert.Equal(t, []Webhook{{URL: "http://localhost:8080/hook"}}, C.Webhooks)
+}
+
+func TestLoadConfigNotifications(t *testing.T) {
+	setupViper()
+	writeAndLoadConfig(t, "")
+
+	assert.Equal(t, []string{"desktop"}, C.Notifications.Backends)
+
+	configYAML := `
+notifications:
+  backends: [desktop, osc777, bell]
+  command: [~/notify.sh, --urgent]
+`
+
+	setupViper()
+	writeAndLoadConfig(t, configYAML)
+
+	homeDir, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"desktop", "osc777", "bell"}, C.Notifications.Backends)
+	assert.Equal(t, plainCommands([]string{filepath.Join(homeDir, "notify.sh"), "--urgent"})[0], C.Notifications.Command)
+
+	t.Setenv("POMO_NOTIFICATIONS_BACKENDS", "command,banner")
+	t.Setenv("POMO_NOTIFICATIONS_COMMAND", `{"command": "notify.sh", "shell": true}`)
+	assert.NoError(t, LoadConfig())
+
+	assert.Equal(t, []string{"command", "banner"}, C.Notifications.Backends)
+	assert.Equal(t, Command{Args: []string{"notify.sh"}, Shell: true}, C.Notifications.Command)
+}
+
+func TestLoadConfigReminder(t *testing.T) {
+	setupViper()
+	writeAndLoadConfig(t, "")
+
+	assert.Zero(t, C.Reminder.Interval, "reminders should be disabled by default")
+	assert.Equal(t, 3, C.Reminder.UrgentAfter)
+
+	configYAML := `
+reminder:
+  interval: 2m
+  urgentAfter: 5
+  message: start the next session!
+`
+
+	setupViper()
+	writeAndLoadConfig(t, configYAML)
+
+	assert.Equal(t, 2*time.Minute, C.Reminder.Interval)
+	assert.Equal(t, 5, C.Reminder.UrgentAfter)
+	assert.Equal(t, "still there? 👀", C.Reminder.Title)
+	assert.Equal(t, "start the next session!", C.Reminder.Message)
+}
+
+func TestLoadConfigActions(t *testing.T) {
+	configYAML := `
+actions:
+  - type: command
+    name: sync notes
+    events: [complete, Quit]
+    options:
+      command: [~/bin/sync-notes, --all]
+      timeout: 30s
+`
+
+	setupViper()
+	writeAndLoadConfig(t, configYAML)
+
+	require.Len(t, C.Actions, 1)
+	action := C.Actions[0]
+
+	assert.Equal(t, "sync notes", action.DisplayName())
+	assert.True(t, action.Wants("quit"))
+	assert.False(t, action.Wants("start"))
+
+	var cmd Command
+	require.NoError(t, action.DecodeOptions(&cmd))
+	assert.Equal(t, Command{Args: []string{"~/bin/sync-notes", "--all"}, Timeout: 30 * time.Second}, cmd)
+
+	assert.Equal(t, "webhook", Action{Type: "webhook"}.DisplayName(), "the name should default to the type")
+
+	t.Setenv("POMO_ACTIONS", `[{"type": "webhook", "options": {"url": "http://localhost:8080"}}]`)
+	assert.NoError(t, LoadConfig())
+	assert.Equal(t, []Action{{Type: "webhook", Options: map[string]any{"url": "http://localhost:8080"}}}, C.Actions)
+}
+
+func TestLoadConfigFlow(t *testing.T) {
+	setupViper()
+	writeAndLoadConfig(t, "")
+
+	assert.InDelta(t, 0.2, float64(C.Flow.Ratio), 0.001)
+	assert.Equal(t, 2*time.Minute, C.Flow.MinBreak)
+
+	configYAML := `
+flow:
+  ratio: 1/4
+  maxBreak: 20m
+  breaks:
+    - upTo: 25m
+      break: 5m
+    - upTo: 50m
+      break: 8m
+`
+
+	setupViper()
+	writeAndLoadConfig(t, configYAML)
+
+	assert.InDelta(t, 0.25, float64(C.Flow.Ratio), 0.001)
+	assert.Equal(t, 20*time.Minute, C.Flow.MaxBreak)
+	assert.Equal(t, []FlowBreak{{UpTo: 25 * time.Minute, Break: 5 * time.Minute}, {UpTo: 50 * time.Minute, Break: 8 * time.Minute}}, C.Flow.Breaks)
+
+	t.Setenv("POMO_FLOW_RATIO", "1/3")
+	assert.NoError(t, LoadConfig())
+	assert.InDelta(t, 1.0/3.0, float64(C.Flow.Ratio), 0.001)
+}
+
+func TestFlowBreakFor(t *testing.T) {
+	ratio := Flow{Ratio: 0.2, MinBreak: 2 * time.Minute, MaxBreak: 15 * time.Minute}
+	table := Flow{Breaks: []FlowBreak{
+		{UpTo: 25 * time.Minute, Break: 5 * time.Minute},
+		{UpTo: 50 * time.Minute, Break: 8 * time.Minute},
+	}}
+
+	testCases := []struct {
+		name     string
+		flow     Flow
+		work     time.Duration
+		expected time.Duration
+	}{
+		{"ratio", ratio, 40 * time.Minute, 8 * time.Minute},
+		{"ratio rounds to seconds", ratio, 31*time.Minute + 1234*time.Millisecond, 6*time.Minute + 12*time.Second},
+		{"min break", ratio, 5 * time.Minute, 2 * time.Minute},
+		{"max break", ratio, 2 * time.Hour, 15 * time.Minute},
+		{"table", table, 25 * time.Minute, 5 * time.Minute},
+		{"table next entry", table, 30 * time.Minute, 8 * time.Minute},
+		{"table past the last entry", table, 90 * time.Minute, 8 * time.Minute},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.flow.BreakFor(tt.work))
+		})
+	}
+}
+
+func TestCommandMarshalJSON(t *testing.T) {
+	data, err := json.Marshal([]Command{
+		{Args: []string{"notify-send", "done"}, ContinueOnError: true},
+		{Args: []string{"sync"}, Timeout: time.Second, Background: true},
+	})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[["notify-send", "done"], {"command": ["sync"], "timeout": 1000000000, "background": true}]`, string(data))
+}
+
+func TestLoadConfigEnvOverrides(t *testing.T) {
+	configYAML := `
+onSessionEnd: ask
+work:
+  duration: 30m
+  title: from file
+`
+
+	t.Setenv("POMO_WORK_DURATION", "50m")
+	t.Setenv("POMO_ONSESSIONEND", "start")
+	t.Setenv("POMO_ASCIIART_FONT", "rebel")
+	t.Setenv("POMO_ASCIIART_ENABLED", "false")
+	t.Setenv("POMO_BREAK_NOTIFICATION_ICON", "~/icon.png")
+	t.Setenv("POMO_BREAK_THEN", `[["echo", "break done"], ["python", "~/done.py"]]`)
+	t.Setenv("POMO_LONGBREAK_AFTER", "2")
+
+	setupViper()
+	writeAndLoadConfig(t, configYAML)
+
+	// env overrides both the file and the defaults
+	assert.Equal(t, 50*time.Minute, C.Work.Duration)
+	assert.Equal(t, "start", C.OnSessionEnd)
+	assert.Equal(t, "rebel", C.ASCIIArt.Font)
+	assert.False(t, C.ASCIIArt.Enabled)
+	assert.Equal(t, homeDir+"/icon.png", C.Break.Notification.Icon)
+	assert.Equal(t, plainCommands([]string{"echo", "break done"}, []string{"python", homeDir + "/done.py"}), C.Break.Then)
+	assert.Equal(t, 2, C.LongBreak.After)
+
+	// values without an env var still come from the file
+	assert.Equal(t, "from file", C.Work.Title)
+}
+
+func TestLoadConfigInvalidEnv(t *testing.T) {
+	t.Setenv("POMO_WORK_THEN", "echo done")
+
+	setupViper()
+	assert.Error(t, LoadConfig(), "non JSON command list should fail")
+}
+
+func TestConfigKeys(t *testing.T) {
+	keys := configKeys()
+
+	assert.Contains(t, keys, "onSessionEnd")
+	assert.Contains(t, keys, "asciiArt.font")
+	assert.Contains(t, keys, "work.duration")
+	assert.Contains(t, keys, "work.then")
+	assert.Contains(t, keys, "break.notification.urgent")
+	assert.Contains(t, keys, "longBreak.after")
+	assert.Contains(t, keys, "longBreak.title", "embedded task fields should be squashed")
+	assert.Contains(t, keys, "longBreak.notification.message")
+	assert.Contains(t, keys, "hooks.onCycleComplete")
+	assert.NotContains(t, keys, "work", "structs should not be keys themselves")
+}
+
+func TestLoadConfigKeys(t *testing.T) {
+	configYAML := `
+keys:
+  timer:
+    pause: [p, space]
+    shortSession: []
+  stats:
+    quit: [x]
+`
+
+	t.Setenv("POMO_KEYS_CONFIRM", `{"confirm": ["enter"]}`)
+
+	setupViper()
+	writeAndLoadConfig(t, configYAML)
+
+	// viper keys are case-insensitive, actions are matched the same way
+	assert.Equal(t, []string{"p", "space"}, C.Keys.Timer["pause"])
+	assert.Equal(t, []string{}, C.Keys.Timer["shortsession"])
+	assert.Equal(t, []string{"x"}, C.Keys.Stats["quit"])
+	assert.Equal(t, []string{"enter"}, C.Keys.Confirm["confirm"])
+}
+
+func TestLoadConfigTheme(t *testing.T) {
+	configYAML := `
+theme:
+  name: light
+  colors:
+    heatMap4: "#000000"
+`
+
+	setupViper()
+	writeAndLoadConfig(t, configYAML)
+
+	assert.Equal(t, "light", C.Theme.Name)
+
+	theme, err := C.Theme.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, lipgloss.Color("#000000"), theme.HeatMap4)
+	assert.Equal(t, colors.Themes["light"].Border, theme.Border)
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config string
+	}{
+		{"unknown onSessionEnd", "onSessionEnd: later"},
+		{"unknown display", "display: tiny"},
+		{"zero work duration", "work:\n  duration: 0s"},
+		{"negative break duration", "break:\n  duration: -5m"},
+		{"malformed duration", "work:\n  duration: soon"},
+		{"malformed yaml", "work: [duration"},
+		{"unknown theme", "theme:\n  name: neon"},
+		{"webhook without url", "webhooks:\n  - events: [start]"},
+		{"webhook with invalid url", "webhooks:\n  - url: ftp://example.com"},
+		{"webhook with unknown event", "webhooks:\n  - url: http://localhost\n    events: [explode]"},
+		{"empty command", "work:\n  then:\n    - []"},
+		{"command without arguments", "hooks:\n  onStart:\n    - timeout: 10s"},
+		{"negative command timeout", "work:\n  then:\n    - command: [sync]\n      timeout: -1s"},
+		{"invalid theme color", "theme:\n  colors:\n    border: red"},
+		{"unknown notification backend", "notifications:\n  backends: [pager]"},
+		{"no notification backends", "notifications:\n  backends: []"},
+		{"negative daily goal", "dailyGoal: -1"},
+		{"zero timer step", "timer:\n  step: 0s"},
+		{"action without type", "actions:\n  - events: [start]"},
+		{"action with unknown event", "actions:\n  - type: command\n    events: [explode]"},
+		{"negative reminder interval", "reminder:\n  interval: -2m"},
+		{"negative reminder urgentAfter", "reminder:\n  urgentAfter: -1"},
+		{"command backend without command", "notifications:\n  backends: [command, bell]"},
+		{"zero flow ratio", "flow:\n  ratio: 0"},
+		{"malformed flow ratio", "flow:\n  ratio: 1/0"},
+		{"flow max break below min", "flow:\n  minBreak: 10m\n  maxBreak: 5m"},
+		{"unsorted flow breaks", "flow:\n  breaks:\n    - upTo: 50m\n      break: 8m\n    - upTo: 25m\n      break: 5m"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			setupViper()
+
+			configFile := filepath.Join(t.TempDir(), ConfigFile)
+			assert.NoError(t, os.WriteFile(configFile, []byte(tt.config), 0o644))
+			layers = []Layer{{Name: "user", Path: configFile}}
+
+			assert.Error(t, LoadConfig())
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	setupViper()
+	writeAndLoadConfig(t, "work:\n  duration: 30m")
+
+	configFile := layers[0].Path
+	assert.NoError(t, os.WriteFile(configFile, []byte("work:\n  duration: 50m"), 0o644))
+
+	cfg, err := Reload()
+	assert.NoError(t, err)
+	assert.Equal(t, 50*time.Minute, cfg.Work.Duration, "reloaded config should have the new duration")
+	assert.Equal(t, 30*time.Minute, C.Work.Duration, "global config should be untouched")
+
+	assert.NoError(t, os.WriteFile(configFile, []byte("onSessionEnd: later"), 0o644))
+
+	_, err = Reload()
+	assert.Error(t, err, "invalid config should fail to reload")
+}
+
+func TestWatchDebounce(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), ConfigFile)
+	require.NoError(t, os.WriteFile(configFile, []byte("work:\n  duration: 30m"), 0o644))
+	layers = []Layer{{Name: "user", Path: configFile}}
+
+	var reloads atomic.Int32
+	Watch(func() { reloads.Add(1) })
+
+	// a save made of several writes
+	for _, content := range []string{"", "work:\n", "work:\n  duration: 50m"} {
+		require.NoError(t, os.WriteFile(configFile, []byte(content), 0o644))
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.Eventually(t, func() bool { return reloads.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	time.Sleep(2 * watchDebounce)
+	assert.Equal(t, int32(1), reloads.Load(), "the writes should be reloaded once")
+}
+
+func TestWatchSerialReloads(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), ConfigFile)
+	require.NoError(t, os.WriteFile(configFile, []byte("work:\n  duration: 30m"), 0o644))
+	layers = []Layer{{Name: "user", Path: configFile}}
+
+	var running, overlapped, reloads atomic.Int32
+	Watch(func() {
+		if running.Add(1) > 1 {
+			overlapped.Store(1)
+		}
+		time.Sleep(3 * watchDebounce)
+		running.Add(-1)
+		reloads.Add(1)
+	})
+
+	// the second change comes while the first one is still reloading
+	require.NoError(t, os.WriteFile(configFile, []byte("work:\n  duration: 40m"), 0o644))
+	time.Sleep(2 * watchDebounce)
+	require.NoError(t, os.WriteFile(configFile, []byte("work:\n  duration: 50m"), 0o644))
+
+	assert.Eventually(t, func() bool { return reloads.Load() == 2 }, 3*time.Second, 10*time.Millisecond)
+	assert.Zero(t, overlapped.Load(), "reloads should not overlap")
+}
+
+func TestLoadConfigLayers(t *testing.T) {
+	userConfig := `
+onSessionEnd: quit
+work:
+  duration: 40m
+  title: personal
+  notification:
+    title: done!
+    urgent: true
+`
+	projectConfig := `
+work:
+  title: project work
+  notification:
+    title: project done!
+  then:
+    - [make, test]
+`
+
+	setupViper()
+
+	dir := t.TempDir()
+	userFile := filepath.Join(dir, "user.yaml")
+	projectFile := filepath.Join(dir, ProjectConfigFile)
+	assert.NoError(t, os.WriteFile(userFile, []byte(userConfig), 0o644))
+	assert.NoError(t, os.WriteFile(projectFile, []byte(projectConfig), 0o644))
+
+	layers = []Layer{{Name: "user", Path: userFile}, {Name: "project", Path: projectFile}}
+	assert.NoError(t, LoadConfig())
+
+	// project values win
+	assert.Equal(t, "project work", C.Work.Title)
+	assert.Equal(t, "project done!", C.Work.Notification.Title)
+	assert.Equal(t, plainCommands([]string{"make", "test"}), C.Work.Then)
+
+	// nested user values are kept
+	assert.Equal(t, "quit", C.OnSessionEnd)
+	assert.Equal(t, 40*time.Minute, C.Work.Duration)
+	assert.True(t, C.Work.Notification.Urgent)
+
+	// and defaults fill in the rest
+	assert.Equal(t, getDefaultConfig().Break.Title, C.Break.Title)
+
+	t.Setenv("POMO_BREAK_DURATION", "7m")
+
+	origins := make(map[string]string)
+	for _, setting := range Settings() {
+		origins[setting.Key] = setting.Origin
+	}
+
+	assert.Equal(t, "project ("+projectFile+")", origins["work.title"])
+	assert.Equal(t, "user ("+userFile+")", origins["work.notification.urgent"])
+	assert.Equal(t, "default", origins["break.title"])
+	assert.Equal(t, "env (POMO_BREAK_DURATION)", origins["break.duration"])
+}
+
+func TestFindProjectConfig(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	nested := filepath.Join(repo, "a", "b")
+	assert.NoError(t, os.MkdirAll(nested, 0o755))
+	assert.NoError(t, os.Mkdir(filepath.Join(repo, ".git"), 0o755))
+
+	// outside the repository, should not be found
+	assert.NoError(t, os.WriteFile(filepath.Join(root, ProjectConfigFile), nil, 0o644))
+
+	_, found := findProjectConfig(nested)
+	assert.False(t, found, "search should stop at the repository root")
+
+	projectFile := filepath.Join(repo, "a", ProjectConfigFile)
+	assert.NoError(t, os.WriteFile(projectFile, nil, 0o644))
+
+	path, found := findProjectConfig(nested)
+	assert.True(t, found)
+	assert.Equal(t, projectFile, path)
+}
+
+func TestFindProjectConfigOutsideRepository(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "a", "b")
+	assert.NoError(t, os.MkdirAll(nested, 0o755))
+
+	// e.g. ~/.pomo.yaml shouldn't be merged into every directory below it
+	assert.NoError(t, os.WriteFile(filepath.Join(root, ProjectConfigFile), nil, 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(nested, ProjectConfigFile), nil, 0o644))
+
+	_, found := findProjectConfig(nested)
+	assert.False(t, found, "there should be no project config outside of a repository")
+}
+
+func TestCamelCase(t *testing.T) {
+	assert.Equal(t, "onSessionEnd", camelCase("OnSessionEnd"))
+	assert.Equal(t, "asciiArt", camelCase("ASCIIArt"))
+	assert.Equal(t, "then", camelCase("Then"))
+	assert.Equal(t, "url", camelCase("URL"))
+}
+
+func TestFindDataFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("XDG variables are only honored on Linux and macOS")
+	}
+
+	dataHome := t.TempDir()
+	dataDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dataHome)
+	t.Setenv("XDG_DATA_DIRS", dataDir)
+
+	assert.Equal(t, []string{filepath.Join(dataHome, AppName), filepath.Join(dataDir, AppName)}, DataDirs())
+
+	systemIcon := filepath.Join(dataDir, AppName, "tomato.png")
+	require.NoError(t, os.MkdirAll(filepath.Dir(systemIcon), 0o755))
+	require.NoError(t, os.WriteFile(systemIcon, nil, 0o644))
+	assert.Equal(t, systemIcon, findDataFile("tomato.png"))
+
+	// the user data directory comes first
+	userIcon := filepath.Join(dataHome, AppName, "tomato.png")
+	require.NoError(t, os.MkdirAll(filepath.Dir(userIcon), 0o755))
+	require.NoError(t, os.WriteFile(userIcon, nil, 0o644))
+	assert.Equal(t, userIcon, findDataFile("tomato.png"))
+
+	assert.Equal(t, "missing.png", findDataFile("missing.png"), "missing files should be kept as is")
+	assert.Equal(t, "icons/tomato.png", findDataFile("icons/tomato.png"), "paths should be kept as is")
+	assert.Equal(t, "", findDataFile(""))
+}
+
 func TestExpandPath(t *testing.T) {
 	testCases := []struct {
 		name  string
@@ -253,11 +882,13 @@ func TestExpandPath(t *testing.T) {
 func setupViper() {
 	viper.Reset()
 	C = Config{} // reset global config
+	layers = nil
 
 	viper.SetConfigName(AppName)
 	viper.SetConfigType("yaml")
 
 	setDefaults()
+	bindEnv()
 }
 
 func writeAndLoadConfig(t *testing.T, config string) {
@@ -267,10 +898,20 @@ func writeAndLoadConfig(t *testing.T, config string) {
 	err := os.WriteFile(configFile, []byte(config), 0o644)
 	assert.NoError(t, err, "Failed to write test config")
 
-	viper.AddConfigPath(tempDir)
+	layers = []Layer{{Name: "user", Path: configFile}}
 	assert.NoError(t, LoadConfig(), "Failed to load config")
 }
 
+// returns commands in the list form
+func plainCommands(args ...[]string) []Command {
+	commands := make([]Command, len(args))
+	for i, arg := range args {
+		commands[i] = Command{Args: arg, ContinueOnError: true}
+	}
+
+	return commands
+}
+
 func getDefaultConfig() Config {
 	// Create a temporary viper instance to unmarshal defaults
 	tempViper := viper.New()
@@ -298,6 +939,9 @@ func assertConfigMatches(t *testing.T, expected Config, actual Config) {
 	assert.Equal(t, expected.ASCIIArt.Font, actual.ASCIIArt.Font)
 	assert.Equal(t, expected.ASCIIArt.Color, actual.ASCIIArt.Color)
 
+	assert.Equal(t, expected.Timer.Step, actual.Timer.Step)
+	assert.Equal(t, expected.Timer.Overtime, actual.Timer.Overtime)
+
 	// work task assertions
 	assert.Equal(t, expected.Work.Duration, actual.Work.Duration)
 	assert.Equal(t, expected.Work.Title, actual.Work.Title)
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Flow computes the break after an open-ended work session of pomo flow,
+// from the ratio of the work time or the breaks lookup table.
+type Flow struct {
+	Ratio    Ratio         // of the break to the work time, e.g. 1/5 or 0.2
+	MinBreak time.Duration // 0 for no lower limit
+	MaxBreak time.Duration // 0 for no upper limit
+
+	// takes precedence over the ratio, e.g. [{upTo: 25m, break: 5m}, {upTo: 50m, break: 8m}]
+	Breaks []FlowBreak
+}
+
+// FlowBreak is the break of work sessions up to a duration,
+// longer sessions than the last entry get its break.
+type FlowBreak struct {
+	UpTo  time.Duration
+	Break time.Duration
+}
+
+// Ratio is a fraction in the config, e.g. 1/5, or a decimal number.
+type Ratio float64
+
+// BreakFor returns the break earned by the given work time, rounded to seconds.
+func (f Flow) BreakFor(work time.Duration) time.Duration {
+	breakTime := time.Duration(float64(work) * float64(f.Ratio))
+
+	if len(f.Breaks) > 0 {
+		breakTime = f.Breaks[len(f.Breaks)-1].Break
+
+		for _, entry := range f.Breaks {
+			if work <= entry.UpTo {
+				breakTime = entry.Break
+				break
+			}
+		}
+	}
+
+	breakTime = max(breakTime, f.MinBreak)
+	if f.MaxBreak > 0 {
+		breakTime = min(breakTime, f.MaxBreak)
+	}
+
+	return breakTime.Round(time.Second)
+}
+
+func (f Flow) validate() error {
+	if f.Ratio < 0 || (f.Ratio == 0 && len(f.Breaks) == 0) {
+		return fmt.Errorf("invalid flow ratio: '%v'", f.Ratio)
+	}
+
+	if f.MinBreak < 0 || f.MaxBreak < 0 {
+		return fmt.Errorf("flow minBreak and maxBreak can't be negative")
+	}
+
+	if f.MaxBreak > 0 && f.MaxBreak < f.MinBreak {
+		return fmt.Errorf("flow maxBreak '%v' is shorter than minBreak '%v'", f.MaxBreak, f.MinBreak)
+	}
+
+	for i, entry := range f.Breaks {
+		if entry.UpTo <= 0 || entry.Break <= 0 {
+			return fmt.Errorf("invalid flow break %d: upTo and break must be positive", i+1)
+		}
+
+		if i > 0 && entry.UpTo <= f.Breaks[i-1].UpTo {
+			return fmt.Errorf("invalid flow break %d: upTo must be longer than the previous one", i+1)
+		}
+	}
+
+	return nil
+}
+
+var ratioType = reflect.TypeFor[Ratio]()
+
+// decodes ratios written as fractions, e.g. 1/5
+func ratioHook(from, to reflect.Type, data any) (any, error) {
+	if to != ratioType || from.Kind() != reflect.String {
+		return data, nil
+	}
+
+	text := strings.TrimSpace(data.(string))
+
+	numerator, denominator, isFraction := strings.Cut(text, "/")
+	if !isFraction {
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ratio %q, expected a fraction like 1/5 or a number", text)
+		}
+		return value, nil
+	}
+
+	n, err := strconv.ParseFloat(strings.TrimSpace(numerator), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ratio %q, expected a fraction like 1/5 or a number", text)
+	}
+
+	d, err := strconv.ParseFloat(strings.TrimSpace(denominator), 64)
+	if err != nil || d == 0 {
+		return nil, fmt.Errorf("invalid ratio %q, expected a fraction like 1/5 or a number", text)
+	}
+
+	return n / d, nil
+}
@@ -0,0 +1,324 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// ProjectConfigFile is looked up from the current directory up to the repository root.
+const ProjectConfigFile = "." + ConfigFile
+
+// editors save in several steps, e.g. truncate, write and rename,
+// so the config is reloaded once the events settle
+const watchDebounce = 150 * time.Millisecond
+
+// Layer is a config file that is deep-merged on top of the layers before it.
+type Layer struct {
+	Name string // system, user, project or local
+	Path string
+}
+
+func (l Layer) String() string {
+	return l.Name + " (" + l.Path + ")"
+}
+
+// Setting is a single config key with its effective value.
+type Setting struct {
+	Key    string
+	Value  any
+	Origin string // where the value came from: env, a layer, or default
+}
+
+// the config files in use, lowest priority first
+var layers []Layer
+
+// Layers returns the config files in use, lowest priority first.
+func Layers() []Layer {
+	return layers
+}
+
+// finds the existing config layers, lowest priority first:
+//  1. system: the first system-wide config file found
+//  2. user: user config directory
+//  3. project: .pomo.yaml in the nearest parent directory, up to the repository root
+//  4. local: pomo.yaml in the current directory
+func findLayers() []Layer {
+	var found []Layer
+
+	// returns whether the file exists
+	add := func(name, path string) bool {
+		path, err := filepath.Abs(path)
+		if err != nil {
+			return false
+		}
+
+		if _, err := os.Stat(path); err != nil {
+			return false
+		}
+
+		// the same file can show up twice, e.g. when running from the user config directory
+		for _, layer := range found {
+			if layer.Path == path {
+				return true
+			}
+		}
+
+		found = append(found, Layer{Name: name, Path: path})
+		return true
+	}
+
+	for _, path := range SystemConfigFiles() {
+		if add("system", path) {
+			break
+		}
+	}
+
+	if path, err := UserConfigFile(); err == nil {
+		add("user", path)
+	} else {
+		log.Println("could not get user config dir:", err)
+	}
+
+	if wd, err := os.Getwd(); err == nil {
+		if path, ok := findProjectConfig(wd); ok {
+			add("project", path)
+		}
+
+		add("local", filepath.Join(wd, ConfigFile))
+	} else {
+		log.Println("could not get working directory:", err)
+	}
+
+	return found
+}
+
+// walks up from dir looking for a project config file, stopping at the repository root,
+// outside of a repository there is no project config
+func findProjectConfig(dir string) (string, bool) {
+	root, ok := findRepositoryRoot(dir)
+	if !ok {
+		return "", false
+	}
+
+	for {
+		path := filepath.Join(dir, ProjectConfigFile)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+
+		if dir == root {
+			return "", false
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// returns the nearest directory from dir up containing .git,
+// a directory or a file for worktrees and submodules
+func findRepositoryRoot(dir string) (string, bool) {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// merges all config layers into viper,
+// replacing any previously read config
+func readLayers() error {
+	viper.SetConfigType("yaml")
+	if err := viper.ReadConfig(strings.NewReader("")); err != nil {
+		return err
+	}
+
+	if len(layers) == 0 {
+		log.Println("no config file found, using defaults")
+		return nil
+	}
+
+	for _, layer := range layers {
+		file, err := os.Open(layer.Path)
+		if err != nil {
+			return err
+		}
+
+		err = viper.MergeConfig(file)
+		_ = file.Close()
+
+		if err != nil {
+			return fmt.Errorf("%v config %v: %w", layer.Name, layer.Path, err)
+		}
+		log.Println("merged config:", layer)
+	}
+
+	return nil
+}
+
+// Watch calls onChange every time one of the config files changes.
+// does nothing if no config file is used.
+func Watch(onChange func()) {
+	if len(layers) == 0 {
+		log.Println("no config file to watch")
+		return
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Println("failed to watch config files:", err)
+		return
+	}
+
+	// watch the directories, editors often replace the file instead of writing to it
+	files := make(map[string]bool)
+	for _, layer := range layers {
+		files[layer.Path] = true
+
+		if err := watcher.Add(filepath.Dir(layer.Path)); err != nil {
+			log.Printf("failed to watch %v: %v", layer, err)
+		}
+	}
+
+	go func() {
+		// fires once the events settle, onChange runs on this goroutine so reloads never overlap
+		var reload <-chan time.Time
+
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				if files[filepath.Clean(event.Name)] && event.Has(fsnotify.Write|fsnotify.Create) {
+					log.Println("config file changed:", event.Name)
+
+					reload = time.After(watchDebounce)
+				}
+
+			case <-reload:
+				reload = nil
+				onChange()
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Println("config watcher error:", err)
+			}
+		}
+	}()
+}
+
+// Settings returns every config key with its value from C
+// and the layer it came from.
+func Settings() []Setting {
+	// read each layer on its own to know which keys it sets
+	layerVipers := make([]*viper.Viper, len(layers))
+	for i, layer := range layers {
+		v := viper.New()
+		v.SetConfigFile(layer.Path)
+
+		if err := v.ReadInConfig(); err != nil {
+			log.Printf("failed to read %v: %v", layer, err)
+		}
+		layerVipers[i] = v
+	}
+
+	settings := flatten(reflect.ValueOf(C), "")
+
+	for i := range settings {
+		settings[i].Origin = "default"
+
+		if envKey := envName(settings[i].Key); os.Getenv(envKey) != "" {
+			settings[i].Origin = "env (" + envKey + ")"
+			continue
+		}
+
+		// highest priority layer first
+		for j := len(layers) - 1; j >= 0; j-- {
+			if layerVipers[j].IsSet(settings[i].Key) {
+				settings[i].Origin = layers[j].String()
+				break
+			}
+		}
+	}
+
+	return settings
+}
+
+// returns every leaf field of the given struct,
+// keys of nested structs are joined with dots
+func flatten(v reflect.Value, prefix string) []Setting {
+	var settings []Setting
+
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		key := prefix + camelCase(field.Name)
+
+		// embedded structs are squashed into their parent
+		if field.Anonymous {
+			settings = append(settings, flatten(v.Field(i), prefix)...)
+			continue
+		}
+
+		// commands are a single value, like in the config file
+		if field.Type.Kind() == reflect.Struct && field.Type != commandType {
+			settings = append(settings, flatten(v.Field(i), key+".")...)
+			continue
+		}
+
+		settings = append(settings, Setting{Key: key, Value: v.Field(i).Interface()})
+	}
+
+	return settings
+}
+
+// returns the keys of all config fields, e.g. work.notification.title
+func configKeys() []string {
+	var keys []string
+
+	for _, setting := range flatten(reflect.ValueOf(Config{}), "") {
+		keys = append(keys, setting.Key)
+	}
+
+	return keys
+}
+
+// returns the environment variable for a config key
+func envName(key string) string {
+	return strings.ToUpper(AppName + "_" + strings.ReplaceAll(key, ".", "_"))
+}
+
+// converts a field name to its config key, e.g. OnSessionEnd -> onSessionEnd, ASCIIArt -> asciiArt
+func camelCase(name string) string {
+	runes := []rune(name)
+
+	for i := range runes {
+		// keep the first letter of the next word, e.g. the A in ASCIIArt
+		if i > 0 && i+1 < len(runes) && unicode.IsUpper(runes[i]) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+
+		if !unicode.IsUpper(runes[i]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+
+	return string(runes)
+}
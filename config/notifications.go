@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// NotificationBackends are the ways a notification can be delivered.
+var NotificationBackends = []string{
+	"desktop", // system notification, fails over SSH without a display
+	"osc9",    // terminal notification escape sequence, e.g. iTerm2, WezTerm, Windows Terminal
+	"osc777",  // terminal notification with a title, e.g. Ghostty, foot, rxvt
+	"bell",
+	"banner", // flashing banner in the timer
+	"command",
+}
+
+// Notifications selects how task notifications are delivered,
+// the backends are tried in order until one of them succeeds.
+type Notifications struct {
+	Backends []string
+
+	// run by the command backend with POMO_NOTIFICATION_TITLE and POMO_NOTIFICATION_MESSAGE set
+	Command Command
+}
+
+// Reminder repeats a notification while the next session prompt is ignored,
+// the title and message are templates like the task notifications.
+type Reminder struct {
+	Interval    time.Duration // 0 disables reminders
+	UrgentAfter int           // reminders sent before they become urgent, 0 to never escalate
+	Title       string
+	Message     string
+}
+
+func (r Reminder) validate() error {
+	if r.Interval < 0 {
+		return fmt.Errorf("invalid reminder interval: '%v'", r.Interval)
+	}
+
+	if r.UrgentAfter < 0 {
+		return fmt.Errorf("invalid reminder urgentAfter: '%v'", r.UrgentAfter)
+	}
+
+	return nil
+}
+
+func (n Notifications) validate() error {
+	if len(n.Backends) == 0 {
+		return fmt.Errorf("no notification backends, expected at least one of: %v", strings.Join(NotificationBackends, ", "))
+	}
+
+	for _, backend := range n.Backends {
+		if !slices.Contains(NotificationBackends, backend) {
+			return fmt.Errorf("unknown notification backend '%v', expected one of: %v", backend, strings.Join(NotificationBackends, ", "))
+		}
+	}
+
+	if slices.Contains(n.Backends, "command") {
+		if err := n.Command.validate(); err != nil {
+			return fmt.Errorf("invalid notification command: %w", err)
+		}
+	}
+
+	return nil
+}
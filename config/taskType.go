@@ -8,10 +8,15 @@ const (
 )
 
 func (t TaskType) GetTask() *Task {
+	return t.From(&C)
+}
+
+// From returns the task of this type from the given config.
+func (t TaskType) From(cfg *Config) *Task {
 	if t == BreakTask {
-		return &C.Break
+		return &cfg.Break
 	}
-	return &C.Work
+	return &cfg.Work
 }
 
 func (t TaskType) Opposite() TaskType {
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Webhook POSTs a JSON event to URL on session events.
+type Webhook struct {
+	URL     string
+	Events  []string          // events to send, all if empty
+	Headers map[string]string // values can reference environment variables, e.g. Bearer $TOKEN
+
+	Timeout time.Duration // per attempt, defaults to 5s
+	Retries int           // attempts after the first one fails
+	Backoff time.Duration // delay before the first retry, doubled after each one, defaults to 1s
+}
+
+// Wants reports whether the webhook should be sent for the event.
+func (w Webhook) Wants(event string) bool {
+	return MatchEvent(w.Events, event)
+}
+
+// Validate checks the URL, events and options of the webhook.
+func (w Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid url '%v', expected an http or https url", w.URL)
+	}
+
+	if err := validateEvents(w.Events); err != nil {
+		return err
+	}
+
+	if w.Timeout < 0 || w.Backoff < 0 || w.Retries < 0 {
+		return fmt.Errorf("timeout, retries and backoff of '%v' can't be negative", w.URL)
+	}
+
+	return nil
+}
@@ -2,13 +2,13 @@
 package db
 
 import (
-	"errors"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
-	"runtime"
 
 	"github.com/Bahaaio/pomo/config"
+	"github.com/Bahaaio/pomo/xdg"
 	"github.com/jmoiron/sqlx"
 	_ "modernc.org/sqlite"
 )
@@ -32,7 +32,14 @@ func Connect() (*sqlx.DB, error) {
 	}
 
 	dbPath := filepath.Join(dbDir, DBFile)
+	migrateDBFile(dbPath)
 
+	return Open(dbPath)
+}
+
+// Open opens the SQLite database at the given path
+// and performs migrations if needed.
+func Open(dbPath string) (*sqlx.DB, error) {
 	db, err := sqlx.Open("sqlite", dbPath)
 	if err != nil {
 		log.Println("failed to connect to the db:", err)
@@ -64,30 +71,72 @@ func createSchema(db *sqlx.DB) error {
 	}
 	log.Println("created the schema")
 
-	return nil
+	return migrate(db)
 }
 
-// returns the path to the db directory
-func getDBDir() (string, error) {
-	var dir string
+// applies any migrations that haven't been applied yet
+func migrate(db *sqlx.DB) error {
+	var version int
+	if err := db.Get(&version, "PRAGMA user_version;"); err != nil {
+		return err
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := db.Beginx()
+		if err != nil {
+			return err
+		}
 
-	// on Linux and macOS, use ~/.local/state
-	if runtime.GOOS == "linux" || runtime.GOOS == "darwin" {
-		dir = os.Getenv("HOME")
-		if dir == "" {
-			return "", errors.New("$HOME is not defined")
+		if _, err = tx.Exec(migrations[i]); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migration %d: %w", i+1, err)
 		}
 
-		dir = filepath.Join(dir, ".local", "state")
-	} else {
-		// on other OSes, use the standard user config directory
-		var err error
-		dir, err = os.UserConfigDir()
-		if err != nil {
-			return "", err
+		// pragmas don't support placeholders
+		if _, err = tx.Exec(fmt.Sprintf("PRAGMA user_version = %d;", i+1)); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+
+		if err = tx.Commit(); err != nil {
+			return err
 		}
+		log.Println("applied migration", i+1)
+	}
+
+	return nil
+}
+
+// Path returns the path of the database file, whether it exists or not.
+func Path() (string, error) {
+	dbDir, err := getDBDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dbDir, DBFile), nil
+}
+
+// returns the path to the db directory
+func getDBDir() (string, error) {
+	dir, err := xdg.StateHome()
+	if err != nil {
+		return "", err
 	}
 
 	// join the dir with the app name
 	return filepath.Join(dir, config.AppName), nil
 }
+
+// moves the db file from ~/.local/state to $XDG_STATE_HOME if needed
+func migrateDBFile(dbPath string) {
+	legacyDir, err := xdg.DefaultStateHome()
+	if err != nil {
+		return
+	}
+
+	legacyPath := filepath.Join(legacyDir, config.AppName, DBFile)
+	if _, err := xdg.Migrate(legacyPath, dbPath); err != nil {
+		log.Println("failed to migrate the db:", err)
+	}
+}
@@ -15,11 +15,116 @@ CREATE TABLE IF NOT EXISTS sessions(
 );
 `
 
+// migrations are applied in order on top of the schema.
+// the number of applied migrations is tracked in the user_version pragma,
+// so new migrations must only ever be appended.
+var migrations = []string{
+	`
+	ALTER TABLE sessions ADD COLUMN title TEXT NOT NULL DEFAULT '';
+	ALTER TABLE sessions ADD COLUMN planned INTEGER NOT NULL DEFAULT 0;
+	`,
+	`
+	CREATE TABLE interruptions(
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		session_id INTEGER NOT NULL REFERENCES sessions(id),
+		kind TEXT NOT NULL,
+		note TEXT NOT NULL DEFAULT '',
+		at TEXT NOT NULL
+	);
+	CREATE INDEX interruptions_session_id ON interruptions(session_id);
+	`,
+	`
+	CREATE TABLE tasks(
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		title TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		done_at TEXT NOT NULL DEFAULT ''
+	);
+	ALTER TABLE sessions ADD COLUMN task_id INTEGER NOT NULL DEFAULT 0;
+	`,
+	`
+	ALTER TABLE tasks ADD COLUMN estimate INTEGER NOT NULL DEFAULT 0;
+	`,
+}
+
 type Session struct {
 	ID        int           `db:"id"`
-	Type      string        `db:"type"`
+	Type      SessionType   `db:"type"`
+	Title     string        `db:"title"`
 	Duration  time.Duration `db:"duration"`
+	Planned   time.Duration `db:"planned"` // zero for sessions recorded before it was tracked
 	StartedAt time.Time     `db:"started_at"`
+	TaskID    int           `db:"task_id"` // zero for sessions without a task
+
+	// logged during the session, saved along with it
+	Interruptions []Interruption `db:"-"`
+}
+
+// Completed reports whether the session ran for its full planned duration.
+// sessions without a planned duration are never considered completed,
+// finished flow sessions are planned to run as long as they did.
+func (s Session) Completed() bool {
+	return s.Planned > 0 && s.Duration >= s.Planned
+}
+
+// Overtime returns the time the session ran past its planned duration.
+func (s Session) Overtime() time.Duration {
+	if s.Planned == 0 {
+		return 0
+	}
+
+	return max(s.Duration-s.Planned, 0)
+}
+
+type InterruptionKind string
+
+const (
+	InternalInterruption InterruptionKind = "internal" // e.g. an urge to check messages
+	ExternalInterruption InterruptionKind = "external" // e.g. a colleague or a call
+)
+
+type Interruption struct {
+	ID        int              `db:"id"`
+	SessionID int              `db:"session_id"`
+	Kind      InterruptionKind `db:"kind"`
+	Note      string           `db:"note"`
+	At        time.Time        `db:"at"`
+}
+
+// Task is planned work from the task queue, work sessions are linked to it once it's picked.
+type Task struct {
+	ID        int       `db:"id"`
+	Title     string    `db:"title"`
+	CreatedAt time.Time `db:"created_at"`
+	DoneAt    time.Time `db:"done_at"`   // zero while the task is open
+	Estimate  int       `db:"estimate"`  // pomodoros, zero if not estimated
+	Pomodoros int       `db:"pomodoros"` // completed work sessions linked to the task
+}
+
+// Done reports whether the task was marked as done.
+func (t Task) Done() bool {
+	return !t.DoneAt.IsZero()
+}
+
+// Accuracy returns how close the pomodoros are to the estimate,
+// from 0 to 1 when they match, e.g. 3 pomodoros for an estimate of 4 is 0.75.
+func (t Task) Accuracy() float64 {
+	if t.Estimate == 0 || t.Pomodoros == 0 {
+		return 0
+	}
+
+	return float64(min(t.Pomodoros, t.Estimate)) / float64(max(t.Pomodoros, t.Estimate))
+}
+
+type DailyInterruptions struct {
+	Date     string `db:"day"`
+	Internal int    `db:"internal"`
+	External int    `db:"external"`
+}
+
+// Total returns the number of interruptions of the day.
+func (d DailyInterruptions) Total() int {
+	return d.Internal + d.External
 }
 
 type AllTimeStats struct {
@@ -16,22 +16,115 @@ func NewSessionRepo(db *sqlx.DB) *SessionRepo {
 	return &SessionRepo{db: db}
 }
 
-// CreateSession inserts a new session record into the database.
-func (r *SessionRepo) CreateSession(startedAt time.Time, duration time.Duration, sessionType SessionType) error {
-	startedAtStr := startedAt.Format(time.RFC3339)
+// CreateSession inserts a new session record into the database,
+// along with its interruptions.
+func (r *SessionRepo) CreateSession(session Session) error {
+	startedAtStr := session.StartedAt.Format(time.RFC3339)
 
-	if _, err := r.db.Exec(
-		"insert into sessions (started_at, duration, type) values (?, ?, ?);",
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.Exec(
+		"insert into sessions (started_at, duration, type, title, planned, task_id) values (?, ?, ?, ?, ?, ?);",
 		startedAtStr,
-		duration,
-		sessionType,
-	); err != nil {
+		session.Duration,
+		session.Type,
+		session.Title,
+		session.Planned,
+		session.TaskID,
+	)
+	if err != nil {
+		return err
+	}
+
+	sessionID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	if err := insertInterruptions(tx, sessionID, session.Interruptions); err != nil {
 		return err
 	}
 
+	return tx.Commit()
+}
+
+// AddInterruptions saves the interruptions under the latest work session,
+// e.g. the one a short session extends.
+func (r *SessionRepo) AddInterruptions(interruptions []Interruption) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var sessionID int64
+	if err := tx.Get(&sessionID, "select id from sessions where type = 'work' order by id desc limit 1;"); err != nil {
+		return err
+	}
+
+	if err := insertInterruptions(tx, sessionID, interruptions); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertInterruptions(tx *sqlx.Tx, sessionID int64, interruptions []Interruption) error {
+	for _, interruption := range interruptions {
+		if _, err := tx.Exec(
+			"insert into interruptions (session_id, kind, note, at) values (?, ?, ?, ?);",
+			sessionID,
+			interruption.Kind,
+			interruption.Note,
+			interruption.At.Format(time.RFC3339),
+		); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// GetSessions retrieves all sessions started between the specified dates.
+// from and to are inclusive.
+func (r *SessionRepo) GetSessions(from, to time.Time) ([]Session, error) {
+	// started_at is stored as text, so it's scanned into a string first
+	var rows []struct {
+		Session
+		StartedAt string `db:"started_at"`
+	}
+
+	if err := r.db.Select(
+		&rows,
+		`
+		SELECT id, type, title, duration, planned, task_id, started_at
+		FROM sessions
+		WHERE date(started_at) BETWEEN ? AND ?
+		ORDER BY started_at;
+		`,
+		from.Format(DateFormat), to.Format(DateFormat),
+	); err != nil {
+		return nil, err
+	}
+
+	sessions := make([]Session, 0, len(rows))
+	for _, row := range rows {
+		startedAt, err := time.Parse(time.RFC3339, row.StartedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		row.Session.StartedAt = startedAt
+		sessions = append(sessions, row.Session)
+	}
+
+	return sessions, nil
+}
+
 // GetAllTimeStats retrieves aggregate statistics across all sessions.
 func (r *SessionRepo) GetAllTimeStats() (AllTimeStats, error) {
 	var totalStats AllTimeStats
@@ -89,6 +182,102 @@ func (r *SessionRepo) GetStreakStats() (StreakStats, error) {
 	return calculateStreak(dates), nil
 }
 
+// GetWorkDuration returns the total work duration recorded on the given day.
+func (r *SessionRepo) GetWorkDuration(day time.Time) (time.Duration, error) {
+	stats, err := r.getDailyStats(day, day)
+	if err != nil {
+		return 0, err
+	}
+
+	return stats[0].WorkDuration, nil
+}
+
+// GetWorkCount returns the number of completed work sessions recorded on the given day.
+func (r *SessionRepo) GetWorkCount(day time.Time) (int, error) {
+	var count int
+
+	if err := r.db.Get(
+		&count,
+		`
+		SELECT COUNT(*)
+		FROM sessions
+		WHERE type = 'work' AND planned > 0 AND duration >= planned AND date(started_at) = ?;
+		`,
+		day.Format(DateFormat),
+	); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetWeeklyInterruptions retrieves the daily interruptions of work sessions for the past 7 days.
+func (r *SessionRepo) GetWeeklyInterruptions() ([]DailyInterruptions, error) {
+	today := time.Now()
+	firstDay := today.AddDate(0, 0, -6)
+
+	fromStr := firstDay.Format(DateFormat)
+	toStr := today.Format(DateFormat)
+
+	var stats []DailyInterruptions
+
+	if err := r.db.Select(
+		&stats,
+		`
+		SELECT
+			date(at) AS day,
+			SUM(kind = 'internal') AS internal,
+			SUM(kind = 'external') AS external
+		FROM interruptions
+		WHERE date(at) BETWEEN ? AND ?
+		GROUP BY day
+		ORDER BY day;
+		`,
+		fromStr, toStr,
+	); err != nil {
+		return nil, err
+	}
+
+	days := make(map[string]DailyInterruptions)
+	for _, stat := range stats {
+		days[stat.Date] = stat
+	}
+
+	// include the days without interruptions
+	normalized := make([]DailyInterruptions, 0, 7)
+	for day := firstDay; !day.After(today); day = day.AddDate(0, 0, 1) {
+		date := day.Format(DateFormat)
+
+		stat := days[date]
+		stat.Date = date
+		normalized = append(normalized, stat)
+	}
+
+	return normalized, nil
+}
+
+// GetRecentTitles returns the distinct titles of the latest work sessions, most recent first.
+func (r *SessionRepo) GetRecentTitles(limit int) ([]string, error) {
+	var titles []string
+
+	if err := r.db.Select(
+		&titles,
+		`
+		SELECT title
+		FROM sessions
+		WHERE type = 'work' AND title != ''
+		GROUP BY title
+		ORDER BY MAX(id) DESC
+		LIMIT ?;
+		`,
+		limit,
+	); err != nil {
+		return nil, err
+	}
+
+	return titles, nil
+}
+
 // retrieves daily work duration statistics between the specified dates.
 // from and to are inclusive.
 // The results are normalized to include all days in the range.
@@ -0,0 +1,205 @@
+package db
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func newTestRepo(t *testing.T) *SessionRepo {
+	database, err := Open(filepath.Join(t.TempDir(), DBFile))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+
+	return NewSessionRepo(database)
+}
+
+func TestMigrateExistingDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), DBFile)
+
+	// create a database with the original schema and a session
+	legacy, err := sqlx.Open("sqlite", dbPath)
+	require.NoError(t, err)
+	_, err = legacy.Exec(schema)
+	require.NoError(t, err)
+	_, err = legacy.Exec(
+		"insert into sessions (started_at, duration, type) values (?, ?, ?);",
+		time.Now().Format(time.RFC3339), time.Minute, WorkSession,
+	)
+	require.NoError(t, err)
+	_ = legacy.Close()
+
+	// reopening should migrate it and keep the session
+	migrated, err := Open(dbPath)
+	require.NoError(t, err)
+	defer func() { _ = migrated.Close() }()
+
+	var version int
+	require.NoError(t, migrated.Get(&version, "PRAGMA user_version;"))
+	assert.Equal(t, len(migrations), version)
+
+	sessions, err := NewSessionRepo(migrated).GetSessions(time.Now(), time.Now())
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "", sessions[0].Title)
+	assert.Zero(t, sessions[0].Planned)
+}
+
+func TestCreateAndGetSessions(t *testing.T) {
+	repo := newTestRepo(t)
+
+	today := time.Now().Truncate(time.Second)
+	lastWeek := today.AddDate(0, 0, -7)
+
+	require.NoError(t, repo.CreateSession(Session{
+		Type: WorkSession, Title: "write report", Duration: 25 * time.Minute, Planned: 25 * time.Minute, StartedAt: today,
+	}))
+	require.NoError(t, repo.CreateSession(Session{
+		Type: BreakSession, Title: "break session", Duration: 5 * time.Minute, Planned: 5 * time.Minute, StartedAt: lastWeek,
+	}))
+
+	sessions, err := repo.GetSessions(today, today)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+
+	session := sessions[0]
+	assert.Equal(t, WorkSession, session.Type)
+	assert.Equal(t, "write report", session.Title)
+	assert.Equal(t, 25*time.Minute, session.Duration)
+	assert.True(t, session.Completed())
+	assert.True(t, today.Equal(session.StartedAt))
+
+	sessions, err = repo.GetSessions(lastWeek, today)
+	require.NoError(t, err)
+	assert.Len(t, sessions, 2)
+}
+
+func TestSessionOvertime(t *testing.T) {
+	testCases := []struct {
+		name     string
+		duration time.Duration
+		planned  time.Duration
+		expected time.Duration
+	}{
+		{"ran past planned", 28 * time.Minute, 25 * time.Minute, 3 * time.Minute},
+		{"finished on time", 25 * time.Minute, 25 * time.Minute, 0},
+		{"skipped early", 10 * time.Minute, 25 * time.Minute, 0},
+		{"not tracked", 30 * time.Minute, 0, 0},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			session := Session{Duration: tt.duration, Planned: tt.planned}
+			assert.Equal(t, tt.expected, session.Overtime())
+		})
+	}
+}
+
+func TestSessionInterruptions(t *testing.T) {
+	repo := newTestRepo(t)
+	now := time.Now()
+
+	require.NoError(t, repo.CreateSession(Session{Type: WorkSession, Duration: 25 * time.Minute, StartedAt: now}))
+	require.NoError(t, repo.CreateSession(Session{
+		Type: WorkSession, Duration: 25 * time.Minute, StartedAt: now,
+		Interruptions: []Interruption{
+			{Kind: InternalInterruption, Note: "checked email", At: now},
+			{Kind: ExternalInterruption, Note: "phone call", At: now},
+			{Kind: InternalInterruption, At: now},
+			{Kind: ExternalInterruption, At: now.AddDate(0, 0, -2)},
+		},
+	}))
+
+	var linked []Interruption
+	require.NoError(t, repo.db.Select(&linked, "SELECT session_id, kind, note FROM interruptions ORDER BY id;"))
+	require.Len(t, linked, 4)
+	assert.Equal(t, 2, linked[0].SessionID, "interruptions should be linked to their session")
+	assert.Equal(t, "checked email", linked[0].Note)
+
+	stats, err := repo.GetWeeklyInterruptions()
+	require.NoError(t, err)
+	require.Len(t, stats, 7)
+
+	assert.Equal(t, DailyInterruptions{Date: now.Format(DateFormat), Internal: 2, External: 1}, stats[6])
+	assert.Equal(t, 1, stats[4].Total())
+	assert.Zero(t, stats[5].Total())
+}
+
+func TestAddInterruptions(t *testing.T) {
+	repo := newTestRepo(t)
+	now := time.Now()
+
+	assert.Error(t, repo.AddInterruptions([]Interruption{{Kind: InternalInterruption, At: now}}), "no work session to add them to")
+
+	require.NoError(t, repo.CreateSession(Session{Type: WorkSession, Duration: 25 * time.Minute, StartedAt: now}))
+	require.NoError(t, repo.CreateSession(Session{Type: BreakSession, Duration: 5 * time.Minute, StartedAt: now}))
+	require.NoError(t, repo.AddInterruptions([]Interruption{{Kind: ExternalInterruption, Note: "doorbell", At: now}}))
+
+	var linked []Interruption
+	require.NoError(t, repo.db.Select(&linked, "SELECT session_id, kind, note FROM interruptions;"))
+	require.Len(t, linked, 1)
+	assert.Equal(t, 1, linked[0].SessionID, "interruptions should be added to the latest work session")
+	assert.Equal(t, "doorbell", linked[0].Note)
+}
+
+func TestGetRecentTitles(t *testing.T) {
+	repo := newTestRepo(t)
+	now := time.Now()
+
+	for _, session := range []Session{
+		{Type: WorkSession, Title: "parser", StartedAt: now},
+		{Type: WorkSession, Title: "review", StartedAt: now},
+		{Type: BreakSession, Title: "break session", StartedAt: now},
+		{Type: WorkSession, Title: "", StartedAt: now},
+		{Type: WorkSession, Title: "parser", StartedAt: now},
+		{Type: WorkSession, Title: "docs", StartedAt: now},
+	} {
+		require.NoError(t, repo.CreateSession(session))
+	}
+
+	titles, err := repo.GetRecentTitles(10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs", "parser", "review"}, titles, "work titles should be distinct and most recent first")
+
+	titles, err = repo.GetRecentTitles(2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs", "parser"}, titles)
+}
+
+func TestGetWorkDuration(t *testing.T) {
+	repo := newTestRepo(t)
+	today := time.Now()
+
+	duration, err := repo.GetWorkDuration(today)
+	require.NoError(t, err)
+	assert.Zero(t, duration)
+
+	for _, session := range []Session{
+		{Type: WorkSession, Duration: 25 * time.Minute, Planned: 25 * time.Minute, StartedAt: today},
+		{Type: WorkSession, Duration: 10 * time.Minute, Planned: 25 * time.Minute, StartedAt: today}, // skipped
+		{Type: BreakSession, Duration: 5 * time.Minute, Planned: 5 * time.Minute, StartedAt: today},
+		{Type: WorkSession, Duration: time.Hour, Planned: time.Hour, StartedAt: today.AddDate(0, 0, -1)},
+	} {
+		require.NoError(t, repo.CreateSession(session))
+	}
+
+	duration, err = repo.GetWorkDuration(today)
+	require.NoError(t, err)
+	assert.Equal(t, 35*time.Minute, duration, "only today's work sessions should count")
+
+	count, err := repo.GetWorkCount(today)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "only today's completed work sessions should count")
+}
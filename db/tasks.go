@@ -0,0 +1,159 @@
+package db
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var ErrTaskNotFound = errors.New("task not found")
+
+type TaskRepo struct {
+	db *sqlx.DB
+}
+
+func NewTaskRepo(db *sqlx.DB) *TaskRepo {
+	return &TaskRepo{db: db}
+}
+
+// CreateTask adds a new open task to the queue,
+// with its estimated pomodoros or zero if it's not estimated.
+func (r *TaskRepo) CreateTask(title string, estimate int) (Task, error) {
+	task := Task{Title: title, CreatedAt: time.Now(), Estimate: estimate}
+
+	result, err := r.db.Exec(
+		"insert into tasks (title, created_at, estimate) values (?, ?, ?);",
+		task.Title,
+		task.CreatedAt.Format(time.RFC3339),
+		task.Estimate,
+	)
+	if err != nil {
+		return Task{}, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return Task{}, err
+	}
+	task.ID = int(id)
+
+	return task, nil
+}
+
+// GetOpenTasks retrieves the tasks that aren't done yet, in the order they were added.
+func (r *TaskRepo) GetOpenTasks() ([]Task, error) {
+	return r.getTasks("WHERE t.done_at = ''", "ORDER BY t.id")
+}
+
+// GetTasks retrieves all tasks, the open ones first.
+func (r *TaskRepo) GetTasks() ([]Task, error) {
+	return r.getTasks("", "ORDER BY t.done_at != '', t.id")
+}
+
+// GetEstimatedTasks retrieves the latest done tasks that were estimated,
+// in the order they were done.
+func (r *TaskRepo) GetEstimatedTasks(limit int) ([]Task, error) {
+	tasks, err := r.getTasks(
+		"WHERE t.done_at != '' AND t.estimate > 0",
+		"ORDER BY t.done_at DESC, t.id DESC LIMIT ?",
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(tasks)
+	return tasks, nil
+}
+
+// SetEstimate sets the estimated pomodoros of the task with the given id.
+func (r *TaskRepo) SetEstimate(id, estimate int) error {
+	result, err := r.db.Exec("update tasks set estimate = ? where id = ?;", estimate, id)
+	if err != nil {
+		return err
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if updated == 0 {
+		return ErrTaskNotFound
+	}
+
+	return nil
+}
+
+// CompleteTask marks the open task with the given id as done.
+func (r *TaskRepo) CompleteTask(id int) error {
+	result, err := r.db.Exec(
+		"update tasks set done_at = ? where id = ? and done_at = '';",
+		time.Now().Format(time.RFC3339),
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if updated == 0 {
+		return ErrTaskNotFound
+	}
+
+	return nil
+}
+
+// retrieves the tasks matching the given where clause in the given order,
+// with their pomodoros, the completed work sessions linked to them
+func (r *TaskRepo) getTasks(where, order string, args ...any) ([]Task, error) {
+	// the dates are stored as text, so they're scanned into strings first
+	var rows []struct {
+		Task
+		CreatedAt string `db:"created_at"`
+		DoneAt    string `db:"done_at"`
+	}
+
+	if err := r.db.Select(
+		&rows,
+		`
+		SELECT t.id, t.title, t.created_at, t.done_at, t.estimate, COUNT(s.id) AS pomodoros
+		FROM tasks t
+		LEFT JOIN sessions s ON s.task_id = t.id AND s.type = 'work'
+			AND s.planned > 0 AND s.duration >= s.planned
+		`+where+`
+		GROUP BY t.id
+		`+order+`;
+		`,
+		args...,
+	); err != nil {
+		return nil, err
+	}
+
+	tasks := make([]Task, 0, len(rows))
+	for _, row := range rows {
+		createdAt, err := time.Parse(time.RFC3339, row.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		row.Task.CreatedAt = createdAt
+
+		if row.DoneAt != "" {
+			doneAt, err := time.Parse(time.RFC3339, row.DoneAt)
+			if err != nil {
+				return nil, err
+			}
+			row.Task.DoneAt = doneAt
+		}
+
+		tasks = append(tasks, row.Task)
+	}
+
+	return tasks, nil
+}
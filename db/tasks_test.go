@@ -0,0 +1,132 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskQueue(t *testing.T) {
+	sessions := newTestRepo(t)
+	tasks := NewTaskRepo(sessions.db)
+	now := time.Now()
+	pomodoro := 25 * time.Minute
+
+	report, err := tasks.CreateTask("write report", 3)
+	require.NoError(t, err)
+	review, err := tasks.CreateTask("review PR", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.ID)
+	assert.Equal(t, 2, review.ID)
+
+	for _, session := range []Session{
+		{Type: WorkSession, TaskID: report.ID, Duration: pomodoro, Planned: pomodoro, StartedAt: now},
+		{Type: WorkSession, TaskID: report.ID, Duration: pomodoro + time.Minute, Planned: pomodoro, StartedAt: now},
+		{Type: WorkSession, TaskID: report.ID, Duration: time.Minute, Planned: pomodoro, StartedAt: now}, // skipped
+		{Type: BreakSession, TaskID: report.ID, Duration: pomodoro, Planned: pomodoro, StartedAt: now},
+		{Type: WorkSession, TaskID: review.ID, Duration: pomodoro, Planned: pomodoro, StartedAt: now},
+		{Type: WorkSession, TaskID: review.ID, Duration: pomodoro, StartedAt: now}, // quit flow session
+		{Type: WorkSession, Duration: pomodoro, Planned: pomodoro, StartedAt: now},
+	} {
+		require.NoError(t, sessions.CreateSession(session))
+	}
+
+	open, err := tasks.GetOpenTasks()
+	require.NoError(t, err)
+	require.Len(t, open, 2)
+	assert.Equal(t, "write report", open[0].Title)
+	assert.Equal(t, 2, open[0].Pomodoros, "only completed work sessions should be counted")
+	assert.Equal(t, 1, open[1].Pomodoros)
+	assert.False(t, open[0].Done())
+
+	require.NoError(t, tasks.CompleteTask(report.ID))
+	assert.ErrorIs(t, tasks.CompleteTask(report.ID), ErrTaskNotFound, "done tasks can't be completed again")
+	assert.ErrorIs(t, tasks.CompleteTask(42), ErrTaskNotFound)
+
+	open, err = tasks.GetOpenTasks()
+	require.NoError(t, err)
+	require.Len(t, open, 1)
+	assert.Equal(t, review.ID, open[0].ID)
+
+	all, err := tasks.GetTasks()
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, review.ID, all[0].ID, "open tasks should come first")
+	assert.True(t, all[1].Done())
+	assert.Equal(t, 2, all[1].Pomodoros)
+}
+
+func TestEstimatedTasks(t *testing.T) {
+	sessions := newTestRepo(t)
+	tasks := NewTaskRepo(sessions.db)
+	now := time.Now()
+	pomodoro := 25 * time.Minute
+
+	for i, task := range []struct {
+		estimate, pomodoros, skipped int
+		done                         bool
+	}{
+		{3, 4, 0, true},
+		{0, 2, 0, true}, // not estimated
+		{2, 2, 1, true},
+		{5, 1, 0, false}, // still open
+		{4, 2, 1, true},
+	} {
+		created, err := tasks.CreateTask("task", task.estimate)
+		require.NoError(t, err)
+
+		for range task.pomodoros {
+			require.NoError(t, sessions.CreateSession(Session{
+				Type: WorkSession, TaskID: created.ID, Duration: pomodoro, Planned: pomodoro, StartedAt: now,
+			}))
+		}
+
+		for range task.skipped {
+			require.NoError(t, sessions.CreateSession(Session{
+				Type: WorkSession, TaskID: created.ID, Duration: 3 * time.Minute, Planned: pomodoro, StartedAt: now,
+			}))
+		}
+
+		if task.done {
+			require.NoError(t, tasks.CompleteTask(created.ID), "task %d", i+1)
+		}
+	}
+
+	estimated, err := tasks.GetEstimatedTasks(2)
+	require.NoError(t, err)
+	require.Len(t, estimated, 2)
+	assert.Equal(t, 3, estimated[0].ID, "the latest tasks should be in the order they were done")
+	assert.Equal(t, 5, estimated[1].ID)
+	assert.Equal(t, 2, estimated[0].Pomodoros, "skipped sessions should not be counted")
+	assert.Equal(t, 1.0, estimated[0].Accuracy())
+	assert.Equal(t, 0.5, estimated[1].Accuracy())
+
+	require.NoError(t, tasks.SetEstimate(2, 1))
+	assert.ErrorIs(t, tasks.SetEstimate(42, 1), ErrTaskNotFound)
+
+	estimated, err = tasks.GetEstimatedTasks(10)
+	require.NoError(t, err)
+	assert.Len(t, estimated, 4)
+}
+
+func TestTaskAccuracy(t *testing.T) {
+	tests := []struct {
+		name     string
+		task     Task
+		expected float64
+	}{
+		{"on estimate", Task{Estimate: 3, Pomodoros: 3}, 1},
+		{"under estimate", Task{Estimate: 4, Pomodoros: 3}, 0.75},
+		{"over estimate", Task{Estimate: 2, Pomodoros: 4}, 0.5},
+		{"not estimated", Task{Pomodoros: 4}, 0},
+		{"not worked on", Task{Estimate: 2}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.task.Accuracy())
+		})
+	}
+}
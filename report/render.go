@@ -0,0 +1,202 @@
+package report
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"text/template"
+	"time"
+)
+
+type Format string
+
+const (
+	Markdown Format = "markdown"
+	HTML     Format = "html"
+)
+
+// ParseFormat returns the format matching the given name.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "markdown", "md":
+		return Markdown, nil
+	case "html":
+		return HTML, nil
+	default:
+		return "", fmt.Errorf("unknown report format: '%v'", name)
+	}
+}
+
+var funcs = map[string]any{
+	"duration": formatDuration,
+	"delta":    formatDelta,
+	"percent":  func(ratio float64) string { return fmt.Sprintf("%.0f%%", ratio*100) },
+	"date":     func(t time.Time) string { return t.Format("Mon 2006-01-02") },
+	"cell":     escapeCell,
+	"inc":      func(i int) int { return i + 1 },
+}
+
+var (
+	markdownTemplate = template.Must(template.New("markdown").Funcs(funcs).Parse(markdownSource))
+	htmlTemplate     = htmltemplate.Must(htmltemplate.New("html").Funcs(funcs).Parse(htmlSource))
+)
+
+// Render renders the report in the given format.
+func (r Report) Render(format Format) (string, error) {
+	var builder strings.Builder
+	var err error
+
+	if format == HTML {
+		err = htmlTemplate.Execute(&builder, r)
+	} else {
+		err = markdownTemplate.Execute(&builder, r)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return builder.String(), nil
+}
+
+// Title returns the report heading, e.g. "Weekly focus report".
+func (r Report) Title() string {
+	if r.Period == Month {
+		return "Monthly focus report"
+	}
+	return "Weekly focus report"
+}
+
+// formats a duration rounded to the minute, e.g. "1h05m"
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+
+	if hours == 0 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+
+	return fmt.Sprintf("%dh%02dm", hours, minutes)
+}
+
+// formats the difference between current and previous values, e.g. "+1h05m (+25%)"
+func formatDelta(current, previous time.Duration) string {
+	diff := current - previous
+
+	sign := "+"
+	if diff < 0 {
+		sign = "-"
+		diff = -diff
+	}
+
+	delta := sign + formatDuration(diff)
+
+	if previous > 0 {
+		change := float64(current-previous) / float64(previous) * 100
+		delta += fmt.Sprintf(" (%+.0f%%)", change)
+	}
+
+	return delta
+}
+
+// escapes pipes so titles don't break markdown tables
+func escapeCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+const markdownSource = `# {{.Title}}
+
+{{date .Range.From}} – {{date .Range.To}}
+
+## Summary
+
+| | This {{.Period}} | Previous {{.Period}} | Change |
+| --- | ---: | ---: | ---: |
+| Focus time | {{duration .Totals.WorkDuration}} | {{duration .Previous.WorkDuration}} | {{delta .Totals.WorkDuration .Previous.WorkDuration}} |
+| Break time | {{duration .Totals.BreakDuration}} | {{duration .Previous.BreakDuration}} | {{delta .Totals.BreakDuration .Previous.BreakDuration}} |
+| Work sessions | {{.Totals.WorkSessions}} | {{.Previous.WorkSessions}} | {{printf "%+d" .SessionsDelta}} |
+
+- **Completion rate:** {{if .Tracked}}{{percent .CompletionRate}} ({{.Completed}}/{{.Tracked}} sessions){{else}}n/a{{end}}
+- **Overtime:** {{duration .Overtime}}
+- **Longest session:** {{with .Longest}}{{duration .Duration}} — {{cell .Title}} on {{date .StartedAt}}{{else}}n/a{{end}}
+- **Streak:** {{.Streak.Current}}d (best {{.Streak.Best}}d)
+
+## Per day
+
+| Day | Focus | Sessions | Break |
+| --- | ---: | ---: | ---: |
+{{- range .Days}}
+| {{date .Date}} | {{duration .WorkDuration}} | {{.WorkSessions}} | {{duration .BreakDuration}} |
+{{- end}}
+
+## Top titles
+{{if .TopTitles}}
+| # | Title | Focus | Sessions |
+| ---: | --- | ---: | ---: |
+{{- range $i, $title := .TopTitles}}
+| {{inc $i}} | {{cell $title.Title}} | {{duration $title.Duration}} | {{$title.Sessions}} |
+{{- end}}
+{{else}}
+No work sessions recorded.
+{{end}}
+_Generated by pomo on {{.GeneratedAt.Format "2006-01-02 15:04"}}_
+`
+
+const htmlSource = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+  body { font-family: sans-serif; max-width: 48rem; margin: 2rem auto; color: #222; }
+  h1, h2 { color: #5A56E0; }
+  table { border-collapse: collapse; width: 100%; margin-bottom: 1.5rem; }
+  th, td { padding: 0.3rem 0.6rem; border-bottom: 1px solid #ddd; text-align: left; }
+  td.num, th.num { text-align: right; }
+  footer { color: #888; font-size: 0.85rem; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{date .Range.From}} – {{date .Range.To}}</p>
+
+<h2>Summary</h2>
+<table>
+  <tr><th></th><th class="num">This {{.Period}}</th><th class="num">Previous {{.Period}}</th><th class="num">Change</th></tr>
+  <tr><td>Focus time</td><td class="num">{{duration .Totals.WorkDuration}}</td><td class="num">{{duration .Previous.WorkDuration}}</td><td class="num">{{delta .Totals.WorkDuration .Previous.WorkDuration}}</td></tr>
+  <tr><td>Break time</td><td class="num">{{duration .Totals.BreakDuration}}</td><td class="num">{{duration .Previous.BreakDuration}}</td><td class="num">{{delta .Totals.BreakDuration .Previous.BreakDuration}}</td></tr>
+  <tr><td>Work sessions</td><td class="num">{{.Totals.WorkSessions}}</td><td class="num">{{.Previous.WorkSessions}}</td><td class="num">{{printf "%+d" .SessionsDelta}}</td></tr>
+</table>
+<ul>
+  <li><strong>Completion rate:</strong> {{if .Tracked}}{{percent .CompletionRate}} ({{.Completed}}/{{.Tracked}} sessions){{else}}n/a{{end}}</li>
+  <li><strong>Overtime:</strong> {{duration .Overtime}}</li>
+  <li><strong>Longest session:</strong> {{with .Longest}}{{duration .Duration}} — {{.Title}} on {{date .StartedAt}}{{else}}n/a{{end}}</li>
+  <li><strong>Streak:</strong> {{.Streak.Current}}d (best {{.Streak.Best}}d)</li>
+</ul>
+
+<h2>Per day</h2>
+<table>
+  <tr><th>Day</th><th class="num">Focus</th><th class="num">Sessions</th><th class="num">Break</th></tr>
+  {{- range .Days}}
+  <tr><td>{{date .Date}}</td><td class="num">{{duration .WorkDuration}}</td><td class="num">{{.WorkSessions}}</td><td class="num">{{duration .BreakDuration}}</td></tr>
+  {{- end}}
+</table>
+
+<h2>Top titles</h2>
+{{if .TopTitles -}}
+<table>
+  <tr><th class="num">#</th><th>Title</th><th class="num">Focus</th><th class="num">Sessions</th></tr>
+  {{- range $i, $title := .TopTitles}}
+  <tr><td class="num">{{inc $i}}</td><td>{{$title.Title}}</td><td class="num">{{duration $title.Duration}}</td><td class="num">{{$title.Sessions}}</td></tr>
+  {{- end}}
+</table>
+{{- else -}}
+<p>No work sessions recorded.</p>
+{{- end}}
+
+<footer>Generated by pomo on {{.GeneratedAt.Format "2006-01-02 15:04"}}</footer>
+</body>
+</html>
+`
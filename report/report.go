@@ -0,0 +1,228 @@
+// Package report builds weekly and monthly focus reports from recorded sessions.
+package report
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/Bahaaio/pomo/db"
+)
+
+const topTitlesCount = 5
+
+type Period int
+
+const (
+	Week Period = iota
+	Month
+)
+
+func (p Period) String() string {
+	if p == Month {
+		return "month"
+	}
+	return "week"
+}
+
+// Range is a span of whole days, from and to are inclusive.
+type Range struct {
+	From time.Time
+	To   time.Time
+}
+
+// NewRange returns the week (starting on Monday) or month containing the given date.
+func NewRange(period Period, date time.Time) Range {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+
+	if period == Month {
+		from := day.AddDate(0, 0, -day.Day()+1)
+		return Range{From: from, To: from.AddDate(0, 1, -1)}
+	}
+
+	// time.Sunday is 0, shift it so the week starts on Monday
+	offset := (int(day.Weekday()) + 6) % 7
+	from := day.AddDate(0, 0, -offset)
+
+	return Range{From: from, To: from.AddDate(0, 0, 6)}
+}
+
+// Previous returns the range of the same period right before this one.
+func (r Range) Previous(period Period) Range {
+	return NewRange(period, r.From.AddDate(0, 0, -1))
+}
+
+// Days returns every day in the range.
+func (r Range) Days() []time.Time {
+	var days []time.Time
+
+	for day := r.From; !day.After(r.To); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+
+	return days
+}
+
+type Totals struct {
+	WorkDuration  time.Duration
+	BreakDuration time.Duration
+	WorkSessions  int
+}
+
+type DayStat struct {
+	Date time.Time
+	Totals
+}
+
+type TitleStat struct {
+	Title    string
+	Duration time.Duration
+	Sessions int
+}
+
+type Report struct {
+	Period Period
+	Range  Range
+
+	Totals   Totals
+	Previous Totals
+
+	Days      []DayStat
+	TopTitles []TitleStat
+
+	// the longest work session, nil if there are none
+	Longest *db.Session
+
+	// work sessions that ran for their full planned duration
+	// out of the ones that have one
+	Completed int
+	Tracked   int
+
+	// time work sessions ran past their planned duration
+	Overtime time.Duration
+
+	Streak      db.StreakStats
+	GeneratedAt time.Time
+}
+
+// CompletionRate returns the ratio of completed work sessions, between 0 and 1.
+func (r Report) CompletionRate() float64 {
+	if r.Tracked == 0 {
+		return 0
+	}
+
+	return float64(r.Completed) / float64(r.Tracked)
+}
+
+// SessionsDelta returns the change in work sessions from the previous period.
+func (r Report) SessionsDelta() int {
+	return r.Totals.WorkSessions - r.Previous.WorkSessions
+}
+
+// Generate builds the report of the given period containing date.
+func Generate(repo *db.SessionRepo, period Period, date time.Time) (Report, error) {
+	current := NewRange(period, date)
+	previous := current.Previous(period)
+
+	sessions, err := repo.GetSessions(current.From, current.To)
+	if err != nil {
+		return Report{}, err
+	}
+
+	previousSessions, err := repo.GetSessions(previous.From, previous.To)
+	if err != nil {
+		return Report{}, err
+	}
+
+	streak, err := repo.GetStreakStats()
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := build(period, current, sessions, previousSessions)
+	report.Streak = streak
+	report.GeneratedAt = time.Now()
+
+	return report, nil
+}
+
+func build(period Period, r Range, sessions, previousSessions []db.Session) Report {
+	report := Report{
+		Period:   period,
+		Range:    r,
+		Totals:   sumTotals(sessions),
+		Previous: sumTotals(previousSessions),
+	}
+
+	days := make(map[string][]db.Session)
+	titles := make(map[string]*TitleStat)
+
+	for _, session := range sessions {
+		day := session.StartedAt.Format(db.DateFormat)
+		days[day] = append(days[day], session)
+
+		if session.Type != db.WorkSession {
+			continue
+		}
+
+		if report.Longest == nil || session.Duration > report.Longest.Duration {
+			report.Longest = &session
+		}
+
+		if session.Planned > 0 {
+			report.Overtime += session.Overtime()
+			report.Tracked++
+			if session.Completed() {
+				report.Completed++
+			}
+		}
+
+		title, exists := titles[session.Title]
+		if !exists {
+			title = &TitleStat{Title: session.Title}
+			titles[session.Title] = title
+		}
+		title.Duration += session.Duration
+		title.Sessions++
+	}
+
+	for _, day := range r.Days() {
+		report.Days = append(report.Days, DayStat{
+			Date:   day,
+			Totals: sumTotals(days[day.Format(db.DateFormat)]),
+		})
+	}
+
+	for _, title := range titles {
+		report.TopTitles = append(report.TopTitles, *title)
+	}
+
+	// longest first, ties broken alphabetically to keep the output stable
+	slices.SortFunc(report.TopTitles, func(a, b TitleStat) int {
+		if c := cmp.Compare(b.Duration, a.Duration); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Title, b.Title)
+	})
+
+	if len(report.TopTitles) > topTitlesCount {
+		report.TopTitles = report.TopTitles[:topTitlesCount]
+	}
+
+	return report
+}
+
+func sumTotals(sessions []db.Session) Totals {
+	var totals Totals
+
+	for _, session := range sessions {
+		if session.Type == db.WorkSession {
+			totals.WorkDuration += session.Duration
+			totals.WorkSessions++
+		} else {
+			totals.BreakDuration += session.Duration
+		}
+	}
+
+	return totals
+}
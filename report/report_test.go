@@ -0,0 +1,120 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Bahaaio/pomo/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(day string) time.Time {
+	t, _ := time.ParseInLocation(db.DateFormat, day, time.Local)
+	return t
+}
+
+func TestNewRange(t *testing.T) {
+	testCases := []struct {
+		name     string
+		period   Period
+		date     string
+		wantFrom string
+		wantTo   string
+	}{
+		{"week from wednesday", Week, "2025-01-08", "2025-01-06", "2025-01-12"},
+		{"week from monday", Week, "2025-01-06", "2025-01-06", "2025-01-12"},
+		{"week from sunday", Week, "2025-01-12", "2025-01-06", "2025-01-12"},
+		{"week across years", Week, "2025-01-01", "2024-12-30", "2025-01-05"},
+		{"month", Month, "2025-01-15", "2025-01-01", "2025-01-31"},
+		{"leap february", Month, "2024-02-29", "2024-02-01", "2024-02-29"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRange(tt.period, date(tt.date))
+
+			assert.Equal(t, tt.wantFrom, r.From.Format(db.DateFormat))
+			assert.Equal(t, tt.wantTo, r.To.Format(db.DateFormat))
+		})
+	}
+}
+
+func TestRangePrevious(t *testing.T) {
+	week := NewRange(Week, date("2025-01-08")).Previous(Week)
+	assert.Equal(t, "2024-12-30", week.From.Format(db.DateFormat))
+	assert.Equal(t, "2025-01-05", week.To.Format(db.DateFormat))
+
+	month := NewRange(Month, date("2025-03-31")).Previous(Month)
+	assert.Equal(t, "2025-02-01", month.From.Format(db.DateFormat))
+	assert.Equal(t, "2025-02-28", month.To.Format(db.DateFormat))
+}
+
+func TestBuild(t *testing.T) {
+	r := NewRange(Week, date("2025-01-08"))
+	monday := date("2025-01-06").Add(9 * time.Hour)
+	tuesday := monday.AddDate(0, 0, 1)
+
+	sessions := []db.Session{
+		{Type: db.WorkSession, Title: "parser", Duration: 25 * time.Minute, Planned: 25 * time.Minute, StartedAt: monday},
+		{Type: db.BreakSession, Title: "break", Duration: 5 * time.Minute, Planned: 5 * time.Minute, StartedAt: monday},
+		{Type: db.WorkSession, Title: "parser", Duration: 10 * time.Minute, Planned: 25 * time.Minute, StartedAt: tuesday},
+		{Type: db.WorkSession, Title: "review", Duration: 50 * time.Minute, Planned: 45 * time.Minute, StartedAt: tuesday},
+		{Type: db.WorkSession, Title: "legacy", Duration: 20 * time.Minute, StartedAt: tuesday},
+	}
+	previous := []db.Session{
+		{Type: db.WorkSession, Title: "parser", Duration: 25 * time.Minute, StartedAt: monday.AddDate(0, 0, -7)},
+	}
+
+	report := build(Week, r, sessions, previous)
+
+	assert.Equal(t, 105*time.Minute, report.Totals.WorkDuration)
+	assert.Equal(t, 5*time.Minute, report.Totals.BreakDuration)
+	assert.Equal(t, 4, report.Totals.WorkSessions)
+	assert.Equal(t, 25*time.Minute, report.Previous.WorkDuration)
+	assert.Equal(t, 3, report.SessionsDelta())
+
+	// per day breakdown covers the whole week
+	assert.Len(t, report.Days, 7)
+	assert.Equal(t, 25*time.Minute, report.Days[0].WorkDuration)
+	assert.Equal(t, 80*time.Minute, report.Days[1].WorkDuration)
+	assert.Equal(t, 3, report.Days[1].WorkSessions)
+	assert.Zero(t, report.Days[2].WorkDuration)
+
+	// titles are sorted by focus time
+	assert.Equal(t, []TitleStat{
+		{Title: "review", Duration: 50 * time.Minute, Sessions: 1},
+		{Title: "parser", Duration: 35 * time.Minute, Sessions: 2},
+		{Title: "legacy", Duration: 20 * time.Minute, Sessions: 1},
+	}, report.TopTitles)
+
+	if assert.NotNil(t, report.Longest) {
+		assert.Equal(t, "review", report.Longest.Title)
+	}
+
+	// sessions without a planned duration are not tracked
+	assert.Equal(t, 2, report.Completed)
+	assert.Equal(t, 3, report.Tracked)
+	assert.InDelta(t, 2.0/3.0, report.CompletionRate(), 0.001)
+	assert.Equal(t, 5*time.Minute, report.Overtime)
+}
+
+func TestRender(t *testing.T) {
+	report := build(Week, NewRange(Week, date("2025-01-08")), []db.Session{
+		{Type: db.WorkSession, Title: "a|b", Duration: time.Hour, StartedAt: date("2025-01-07")},
+	}, nil)
+
+	markdown, err := report.Render(Markdown)
+	assert.NoError(t, err)
+	assert.Contains(t, markdown, "# Weekly focus report")
+	assert.Contains(t, markdown, `| 1 | a\|b | 1h00m | 1 |`)
+
+	html, err := report.Render(HTML)
+	assert.NoError(t, err)
+	assert.Contains(t, html, "<h1>Weekly focus report</h1>")
+}
+
+func TestFormatDelta(t *testing.T) {
+	assert.Equal(t, "+30m (+50%)", formatDelta(90*time.Minute, time.Hour))
+	assert.Equal(t, "-1h00m (-50%)", formatDelta(time.Hour, 2*time.Hour))
+	assert.Equal(t, "+25m", formatDelta(25*time.Minute, 0))
+}
@@ -10,7 +10,18 @@ import (
 )
 
 func (m Model) Init() tea.Cmd {
-	return m.timer.Init()
+	m.dispatch("start")
+
+	start := m.timer.Init()
+	if m.stopwatch {
+		start = countUpTick(m.startedAt)
+	}
+
+	return tea.Batch(
+		start,
+		m.waitForCommandResult(),
+		m.waitForNotice(),
+	)
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -24,6 +35,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case timer.TickMsg:
 		return m, m.handleTimerTick(msg)
 
+	case countUpTickMsg:
+		return m, m.handleCountUpTick(msg)
+
 	case confirmTickMsg:
 		return m, m.handleConfirmTick()
 
@@ -39,6 +53,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case commandsDoneMsg:
 		return m, m.handleCommandsDone()
 
+	case commandResultMsg:
+		return m, m.handleCommandResult(msg)
+
+	case ConfigReloadedMsg:
+		return m, m.handleConfigReload(msg)
+
+	case bannerTimeoutMsg:
+		return m, m.handleBannerTimeout(msg)
+
+	case bannerFlashMsg:
+		return m, m.handleBannerFlash(msg)
+
+	case noticeMsg:
+		return m, m.handleNotice(msg)
+
 	default:
 		return m, nil
 	}
@@ -49,6 +78,10 @@ func (m Model) View() string {
 		return ""
 	}
 
+	if m.inline {
+		return m.buildInlineView()
+	}
+
 	if m.sessionState == WaitingForCommands {
 		return m.buildWaitingForCommandsView()
 	}
@@ -64,9 +97,14 @@ func (m Model) View() string {
 
 	help := m.buildHelpView()
 
+	view := lipgloss.JoinVertical(lipgloss.Center, content, help)
+	if banner := m.buildBanner(); banner != "" {
+		view = lipgloss.JoinVertical(lipgloss.Center, view, "", banner)
+	}
+
 	return lipgloss.Place(
 		m.width, m.height,
 		lipgloss.Center, lipgloss.Center,
-		lipgloss.JoinVertical(lipgloss.Center, content, help),
+		view,
 	)
 }
@@ -1,6 +1,6 @@
 package ascii
 
-type Font [11]string
+type Font [12]string
 
 const (
 	Mono12      = "mono12"
@@ -110,6 +110,15 @@ var fonts = map[string]Font{
     ██    
     ▀▀    
 
+`,
+		`
+
+          
+    ▄▄    
+ ▄▄▄██▄▄▄ 
+ ▀▀▀██▀▀▀ 
+    ▀▀    
+
 `,
 	},
 
@@ -234,6 +243,17 @@ var fonts = map[string]Font{
     ▒▒      
             
             
+`,
+		`
+            
+            
+     ██     
+   ██████   
+  ▒▒▒██▒▒▒  
+    ▒▒      
+            
+            
+            
 `,
 	},
 
@@ -324,6 +344,13 @@ var fonts = map[string]Font{
          
    ██    
          
+`,
+		`
+         
+   ██    
+ ██████  
+   ██    
+         
 `,
 	},
 
@@ -425,6 +452,14 @@ var fonts = map[string]Font{
    ██╗   
    ╚═╝   
          
+`,
+		`
+         
+   ██╗   
+ ██████╗ 
+ ╚═██╔═╝ 
+   ╚═╝   
+         
 `,
 	},
 }
@@ -24,9 +24,18 @@ func GetFont(fontName string) Font {
 	return fonts[DefaultFont]
 }
 
+// indexes of the symbols in a font, after the digits
+const (
+	colon = 10
+	plus  = 11
+)
+
 func renderDigit(digit rune, font Font) string {
-	if digit == ':' {
-		return font[len(font)-1]
+	switch digit {
+	case ':':
+		return font[colon]
+	case '+':
+		return font[plus]
 	}
 
 	if digit < '0' || digit > '9' {
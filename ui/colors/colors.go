@@ -1,4 +1,4 @@
-// Package colors defines color constants for UI elements.
+// Package colors defines the color palette and themes for UI elements.
 package colors
 
 import (
@@ -22,37 +22,10 @@ const (
 	Green       = lipgloss.Color("#198754")
 	Blue        = lipgloss.Color("#4A9EFF")
 	DimGray     = lipgloss.Color("#606060")
+	Magenta     = lipgloss.Color("#EE6FF8")
 	NoColor     = lipgloss.Color("default")
 )
 
-const (
-	// Timer & primary UI
-	TimerFg  = Indigo
-	BorderFg = Purple
-	PauseFg  = DimGray
-
-	// heat map
-	HeatMapFg0 = DimGray
-	HeatMapFg1 = PurpleDark
-	HeatMapFg2 = Purple
-	HeatMapFg3 = PurpleLight
-	HeatMapFg4 = PurplePale
-
-	// Session types
-	WorkSessionFg  = Purple
-	BreakSessionFg = NoColor
-
-	// Buttons
-	InactiveButtonFg = Cream
-	InactiveButtonBg = Gray
-	ActiveButtonFg   = Cream
-	ActiveButtonBg   = Pink
-
-	// Messages
-	SuccessMessageFg = Green
-	ErrorMessageFg   = Red
-)
-
 var validColorRegex *regexp.Regexp = nil
 
 func init() {
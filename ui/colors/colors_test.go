@@ -40,3 +40,45 @@ func TestValidHexColor(t *testing.T) {
 		})
 	}
 }
+
+func TestNewTheme(t *testing.T) {
+	for _, name := range colors.ThemeNames() {
+		t.Run(name, func(t *testing.T) {
+			theme, err := colors.NewTheme(name, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, colors.Themes[name], theme)
+		})
+	}
+
+	theme, err := colors.NewTheme("Solarized", map[string]string{
+		"border":   "#000000",
+		"HEATMAP1": "none",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, lipgloss.Color("#000000"), theme.Border, "overrides are case-insensitive")
+	assert.Equal(t, colors.NoColor, theme.HeatMap1)
+	assert.Equal(t, colors.Themes["solarized"].Timer, theme.Timer, "other colors keep the theme value")
+
+	// built-in themes are never modified
+	assert.NotEqual(t, lipgloss.Color("#000000"), colors.Themes["solarized"].Border)
+}
+
+func TestNewThemeErrors(t *testing.T) {
+	testCases := []struct {
+		name      string
+		theme     string
+		overrides map[string]string
+	}{
+		{"unknown theme", "neon", nil},
+		{"unknown color", "dark", map[string]string{"background": "#000000"}},
+		{"invalid color", "dark", map[string]string{"border": "red"}},
+		{"progress without color", "dark", map[string]string{"progressEnd": "none"}},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := colors.NewTheme(tt.theme, tt.overrides)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package colors
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const DefaultTheme = "dark"
+
+// Theme holds the colors of every UI element.
+type Theme struct {
+	// timer & primary UI
+	Timer  lipgloss.Color
+	Border lipgloss.Color
+	Pause  lipgloss.Color
+	Dim    lipgloss.Color
+
+	// timer counting past zero in overtime mode
+	Overtime lipgloss.Color
+
+	// progress bar gradient, must be hex colors
+	ProgressStart lipgloss.Color
+	ProgressEnd   lipgloss.Color
+
+	// heat map, from no activity to the most
+	HeatMap0 lipgloss.Color
+	HeatMap1 lipgloss.Color
+	HeatMap2 lipgloss.Color
+	HeatMap3 lipgloss.Color
+	HeatMap4 lipgloss.Color
+
+	// session types
+	WorkSession  lipgloss.Color
+	BreakSession lipgloss.Color
+
+	// buttons
+	InactiveButtonFg lipgloss.Color
+	InactiveButtonBg lipgloss.Color
+	ActiveButtonFg   lipgloss.Color
+	ActiveButtonBg   lipgloss.Color
+
+	// messages
+	SuccessMessage lipgloss.Color
+	ErrorMessage   lipgloss.Color
+}
+
+// Themes are the built-in themes.
+var Themes = map[string]Theme{
+	"dark": {
+		Timer:            Indigo,
+		Border:           Purple,
+		Pause:            DimGray,
+		Dim:              DimGray,
+		Overtime:         Pink,
+		ProgressStart:    Indigo,
+		ProgressEnd:      Magenta,
+		HeatMap0:         DimGray,
+		HeatMap1:         PurpleDark,
+		HeatMap2:         Purple,
+		HeatMap3:         PurpleLight,
+		HeatMap4:         PurplePale,
+		WorkSession:      Purple,
+		BreakSession:     NoColor,
+		InactiveButtonFg: Cream,
+		InactiveButtonBg: Gray,
+		ActiveButtonFg:   Cream,
+		ActiveButtonBg:   Pink,
+		SuccessMessage:   Green,
+		ErrorMessage:     Red,
+	},
+	"light": {
+		Timer:            "#3C3AB0",
+		Border:           "#6A3FD0",
+		Pause:            "#9A9A9A",
+		Dim:              "#808080",
+		Overtime:         "#D6336C",
+		ProgressStart:    "#3C3AB0",
+		ProgressEnd:      "#D6336C",
+		HeatMap0:         "#D0D0D0",
+		HeatMap1:         "#C3A1FF",
+		HeatMap2:         "#A070FF",
+		HeatMap3:         "#7A3FE0",
+		HeatMap4:         "#4B1FA8",
+		WorkSession:      "#6A3FD0",
+		BreakSession:     "#B0B0B0",
+		InactiveButtonFg: "#FFFFFF",
+		InactiveButtonBg: "#9A9A9A",
+		ActiveButtonFg:   "#FFFFFF",
+		ActiveButtonBg:   "#D6336C",
+		SuccessMessage:   "#146C43",
+		ErrorMessage:     "#C62828",
+	},
+	"high-contrast": {
+		Timer:            "#FFFF00",
+		Border:           "#FFFFFF",
+		Pause:            "#A0A0A0",
+		Dim:              "#C0C0C0",
+		Overtime:         "#FF5555",
+		ProgressStart:    "#00FFFF",
+		ProgressEnd:      "#FFFF00",
+		HeatMap0:         "#505050",
+		HeatMap1:         "#008700",
+		HeatMap2:         "#00AF00",
+		HeatMap3:         "#00FF00",
+		HeatMap4:         "#AFFF00",
+		WorkSession:      "#00FFFF",
+		BreakSession:     "#FFFFFF",
+		InactiveButtonFg: "#000000",
+		InactiveButtonBg: "#C0C0C0",
+		ActiveButtonFg:   "#000000",
+		ActiveButtonBg:   "#FFFF00",
+		SuccessMessage:   "#00FF00",
+		ErrorMessage:     "#FF5555",
+	},
+	"solarized": {
+		Timer:            "#6C71C4",
+		Border:           "#268BD2",
+		Pause:            "#586E75",
+		Dim:              "#586E75",
+		Overtime:         "#CB4B16",
+		ProgressStart:    "#268BD2",
+		ProgressEnd:      "#D33682",
+		HeatMap0:         "#586E75",
+		HeatMap1:         "#2AA198",
+		HeatMap2:         "#268BD2",
+		HeatMap3:         "#6C71C4",
+		HeatMap4:         "#D33682",
+		WorkSession:      "#268BD2",
+		BreakSession:     "#93A1A1",
+		InactiveButtonFg: "#FDF6E3",
+		InactiveButtonBg: "#586E75",
+		ActiveButtonFg:   "#FDF6E3",
+		ActiveButtonBg:   "#D33682",
+		SuccessMessage:   "#859900",
+		ErrorMessage:     "#DC322F",
+	},
+}
+
+// Current is the active theme.
+var Current = Themes[DefaultTheme]
+
+// NewTheme returns the built-in theme with the given name and overrides applied.
+// overrides are keyed by the case-insensitive color name, e.g. heatMap1,
+// and take a hex color or "none".
+func NewTheme(name string, overrides map[string]string) (Theme, error) {
+	theme, exists := Themes[strings.ToLower(name)]
+	if !exists {
+		return Theme{}, fmt.Errorf("unknown theme '%v', expected one of: %v", name, strings.Join(ThemeNames(), ", "))
+	}
+
+	v := reflect.ValueOf(&theme).Elem()
+
+	for key, value := range overrides {
+		field := v.FieldByNameFunc(func(field string) bool {
+			return strings.EqualFold(field, key)
+		})
+
+		if !field.IsValid() {
+			return Theme{}, fmt.Errorf("unknown theme color '%v'", key)
+		}
+
+		if !isValidColor(value) && value != "none" {
+			return Theme{}, fmt.Errorf("invalid color '%v' for '%v', expected a hex color or 'none'", value, key)
+		}
+
+		if value == "none" {
+			value = string(NoColor)
+		}
+		field.SetString(value)
+	}
+
+	if !isValidColor(string(theme.ProgressStart)) || !isValidColor(string(theme.ProgressEnd)) {
+		return Theme{}, fmt.Errorf("progress bar colors must be hex colors")
+	}
+
+	return theme, nil
+}
+
+// ThemeNames returns the names of the built-in themes, sorted.
+func ThemeNames() []string {
+	names := make([]string, 0, len(Themes))
+	for name := range Themes {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+	return names
+}
+
+func isValidColor(color string) bool {
+	return validColorRegex != nil && validColorRegex.MatchString(color)
+}
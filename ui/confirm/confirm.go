@@ -2,6 +2,7 @@
 package confirm
 
 import (
+	"strings"
 	"time"
 
 	"github.com/Bahaaio/pomo/ui/colors"
@@ -29,25 +30,26 @@ var (
 
 	borderStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(colors.BorderFg).
 			Padding(borderPadding...).
 			BorderTop(true)
 
-	InactiveButtonStyle = lipgloss.NewStyle().
-				Foreground(colors.InactiveButtonFg).
-				Background(colors.InactiveButtonBg).
-				Padding(buttonPadding...).
-				Margin(buttonMargin...)
+	buttonStyle = lipgloss.NewStyle().
+			Padding(buttonPadding...).
+			Margin(buttonMargin...)
+)
 
-	activeButtonStyle = InactiveButtonStyle.
-				Foreground(colors.ActiveButtonFg).
-				Background(colors.ActiveButtonBg).
-				Padding(buttonPadding...).
-				Margin(buttonMargin...)
+// InactiveButtonStyle returns the style of an unselected button in the current theme.
+func InactiveButtonStyle() lipgloss.Style {
+	return buttonStyle.
+		Foreground(colors.Current.InactiveButtonFg).
+		Background(colors.Current.InactiveButtonBg)
+}
 
-	idleStyle = lipgloss.NewStyle().
-			Foreground(colors.DimGray)
-)
+func activeButtonStyle() lipgloss.Style {
+	return buttonStyle.
+		Foreground(colors.Current.ActiveButtonFg).
+		Background(colors.Current.ActiveButtonBg)
+}
 
 type ConfirmChoice int
 
@@ -75,7 +77,9 @@ func New() Model {
 	}
 }
 
-func (m Model) View(prompt string, idleDuration time.Duration) string {
+// View renders the dialog with the given prompt,
+// message is an optional line shown below the idle time.
+func (m Model) View(prompt string, idleDuration time.Duration, message string) string {
 	if m.quitting {
 		return ""
 	}
@@ -85,20 +89,20 @@ func (m Model) View(prompt string, idleDuration time.Duration) string {
 	var confirmButton, cancelButton string
 
 	if m.confirmed {
-		confirmButton = activeButtonStyle.Render(confirmText)
-		cancelButton = InactiveButtonStyle.Render(cancelText)
+		confirmButton = activeButtonStyle().Render(confirmText)
+		cancelButton = InactiveButtonStyle().Render(cancelText)
 	} else {
-		confirmButton = InactiveButtonStyle.Render(confirmText)
-		cancelButton = activeButtonStyle.Render(cancelText)
+		confirmButton = InactiveButtonStyle().Render(confirmText)
+		cancelButton = activeButtonStyle().Render(cancelText)
 	}
 
 	buttons := lipgloss.JoinHorizontal(lipgloss.Right, confirmButton, cancelButton)
 	dialog := lipgloss.JoinVertical(lipgloss.Center, prompt, "\n", buttons)
-	ui := borderStyle.Render(dialog)
+	ui := borderStyle.BorderForeground(colors.Current.Border).Render(dialog)
 
 	idle := ""
 	if idleDuration.Seconds() > 0 {
-		idle = idleStyle.Render("idle for " + idleDuration.String())
+		idle = lipgloss.NewStyle().Foreground(colors.Current.Dim).Render("idle for " + idleDuration.String())
 	}
 
 	help := m.help.View(Keys)
@@ -111,12 +115,50 @@ func (m Model) View(prompt string, idleDuration time.Duration) string {
 			ui,
 			"",
 			idle,
+			message,
 			"",
 			help,
 		),
 	)
 }
 
+// InlineView renders the dialog on a single line for the inline display,
+// e.g. start break session? Yes No (y/n/s) · idle for 1m0s
+func (m Model) InlineView(prompt string, idleDuration time.Duration) string {
+	if m.quitting {
+		return ""
+	}
+
+	inlineButton := lipgloss.NewStyle().Padding(0, 1)
+	confirmButton := inlineButton.Inherit(InactiveButtonStyle()).Render(confirmText)
+	cancelButton := inlineButton.Inherit(activeButtonStyle()).Render(cancelText)
+
+	if m.confirmed {
+		confirmButton = inlineButton.Inherit(activeButtonStyle()).Render(confirmText)
+		cancelButton = inlineButton.Inherit(InactiveButtonStyle()).Render(cancelText)
+	}
+
+	dim := lipgloss.NewStyle().Foreground(colors.Current.Dim)
+
+	var keys []string
+	for _, binding := range []key.Binding{Keys.Confirm, Keys.Cancel, Keys.ShortSession} {
+		if binding.Enabled() {
+			keys = append(keys, binding.Help().Key)
+		}
+	}
+
+	view := promptStyle.Render(prompt) + " " + confirmButton + " " + cancelButton
+	if len(keys) > 0 {
+		view += dim.Render(" (" + strings.Join(keys, "/") + ")")
+	}
+
+	if idleDuration.Seconds() > 0 {
+		view += dim.Render(" · idle for " + idleDuration.String())
+	}
+
+	return view
+}
+
 func (m *Model) HandleKeys(msg tea.KeyMsg) tea.Cmd {
 	switch {
 	case key.Matches(msg, Keys.Confirm):
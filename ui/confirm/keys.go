@@ -26,7 +26,10 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{}
 }
 
-var Keys = KeyMap{
+// Keys are the active key bindings, see [DefaultKeys]
+var Keys = DefaultKeys
+
+var DefaultKeys = KeyMap{
 	Toggle: key.NewBinding(
 		key.WithKeys("tab", "h", "l", "left", "right"),
 		key.WithHelp("", "toggle"),
@@ -2,13 +2,18 @@ package ui
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Bahaaio/pomo/actions"
 	"github.com/Bahaaio/pomo/config"
 	"github.com/Bahaaio/pomo/db"
+	"github.com/Bahaaio/pomo/ui/colors"
 	"github.com/Bahaaio/pomo/ui/confirm"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/progress"
@@ -16,16 +21,75 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+const (
+	bannerTimeout = 3 * time.Second
+
+	// lower bound of the time left when the time is decreased or set
+	minRemaining = 10 * time.Second
+
+	// notification banners stay longer and flash to get noticed
+	noticeTimeout = 10 * time.Second
+	flashInterval = 500 * time.Millisecond
+
+	// titles suggested by the rename input
+	recentTitlesLimit = 50
+
+	// open tasks shown at once by the task picker
+	taskPickerHeight = 5
+
+	// pending command results and notices before new ones are dropped
+	commandResultsSize = 16
+	noticesSize        = 4
+)
+
 type (
 	confirmTickMsg  struct{}
 	commandsDoneMsg struct{}
+	countUpTickMsg  struct {
+		startedAt time.Time // of the session, so ticks of an earlier session are ignored
+	}
+	bannerTimeoutMsg struct {
+		id int
+	}
+	bannerFlashMsg struct {
+		id int
+	}
+	commandResultMsg struct {
+		result actions.CommandResult
+	}
+	noticeMsg struct {
+		notice actions.Notice
+	}
 )
 
+// ConfigReloadedMsg is sent when the config file changes while running.
+// Err is set if the new config could not be loaded.
+type ConfigReloadedMsg struct {
+	Config config.Config
+	Err    error
+}
+
 func (m *Model) handleKeys(msg tea.KeyMsg) tea.Cmd {
 	if m.sessionState == ShowingConfirm {
 		return m.confirmDialog.HandleKeys(msg)
 	}
 
+	if m.editingTime {
+		return m.handleTimeInputKeys(msg)
+	}
+
+	if m.editingTitle {
+		return m.handleTitleInputKeys(msg)
+	}
+
+	if m.editingNote {
+		return m.handleNoteInputKeys(msg)
+	}
+
+	if m.pickingTask {
+		return m.handleTaskPickerKeys(msg)
+	}
+
 	if m.sessionState == WaitingForCommands {
 		// allow quitting immediately while waiting for commands
 		if key.Matches(msg, keyMap.Quit) {
@@ -36,22 +100,47 @@ func (m *Model) handleKeys(msg tea.KeyMsg) tea.Cmd {
 		return nil
 	}
 
+	if m.countingUp() {
+		return m.handleCountUpKeys(msg)
+	}
+
 	switch {
 	case key.Matches(msg, keyMap.Increase):
-		m.duration += time.Minute
-		return m.updateProgressBar()
+		return m.setDuration(m.duration + m.step)
+
+	case key.Matches(msg, keyMap.Decrease):
+		return m.setDuration(m.duration - m.step)
+
+	case key.Matches(msg, keyMap.SetTime):
+		return m.openTimeInput()
+
+	case key.Matches(msg, keyMap.Rename):
+		return m.openTitleInput()
+
+	case key.Matches(msg, keyMap.Task):
+		m.openTaskPicker()
+		return nil
+
+	case key.Matches(msg, keyMap.Internal):
+		return m.openNoteInput(db.InternalInterruption)
+
+	case key.Matches(msg, keyMap.External):
+		return m.openNoteInput(db.ExternalInterruption)
 
 	case key.Matches(msg, keyMap.Pause):
 		if m.sessionState == Paused {
 			m.sessionState = Running
-		} else if m.getPercent() != 1.0 { // prevent pausing if session is already completed
-			m.sessionState = Paused
+			m.dispatch("resume")
+			return m.timer.Start()
 		}
 
-		if m.sessionState == Running {
-			return m.timer.Start()
+		// prevent pausing if session is already completed
+		if m.getPercent() == 1.0 {
+			return nil
 		}
 
+		m.sessionState = Paused
+		m.dispatch("pause")
 		return nil
 
 	case key.Matches(msg, keyMap.Reset):
@@ -60,22 +149,349 @@ func (m *Model) handleKeys(msg tea.KeyMsg) tea.Cmd {
 		return m.updateProgressBar()
 
 	case key.Matches(msg, keyMap.Skip):
+		m.dispatch("skip")
 		m.recordSession()
 		return m.nextSession()
 
 	case key.Matches(msg, keyMap.Quit):
-		m.recordSession()
-		return m.Quit()
+		return m.quitSession()
 
 	default:
 		return nil
 	}
 }
 
+// handles the keys while counting up in overtime or a flow session,
+// the session is recorded once it's finished
+func (m *Model) handleCountUpKeys(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, keyMap.Pause):
+		if m.sessionState == Paused {
+			m.sessionState = Running
+			m.dispatch("resume")
+		} else {
+			m.sessionState = Paused
+			m.dispatch("pause")
+		}
+
+		return nil
+
+	case key.Matches(msg, keyMap.Finish):
+		// overtime starts after the session completed,
+		// a flow session completes once it's finished, planned to run as long as it did
+		completed := m.stopwatch
+		if completed {
+			m.duration = m.elapsed
+		}
+		m.endCountUp()
+
+		if completed {
+			m.postActions = m.dispatch("complete")
+		}
+		return m.continueAfterSession()
+
+	case key.Matches(msg, keyMap.Skip):
+		if m.stopwatch {
+			m.dispatch("skip")
+		}
+		m.endCountUp()
+		return m.nextSession()
+
+	case key.Matches(msg, keyMap.Rename):
+		return m.openTitleInput()
+
+	case key.Matches(msg, keyMap.Task):
+		m.openTaskPicker()
+		return nil
+
+	case key.Matches(msg, keyMap.Internal):
+		return m.openNoteInput(db.InternalInterruption)
+
+	case key.Matches(msg, keyMap.External):
+		return m.openNoteInput(db.ExternalInterruption)
+
+	case key.Matches(msg, keyMap.Quit):
+		return m.quitSession()
+
+	default:
+		return nil
+	}
+}
+
+// opens the inline input to set the remaining time
+func (m *Model) openTimeInput() tea.Cmd {
+	m.editingTime = true
+	m.editTotal = false
+	m.timeInput.Prompt = "remaining: "
+	m.timeInput.SetValue("")
+
+	return m.timeInput.Focus()
+}
+
+func (m *Model) closeTimeInput() {
+	m.editingTime = false
+	m.timeInput.Blur()
+}
+
+// the timer keeps running while the time is typed
+func (m *Model) handleTimeInputKeys(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		m.closeTimeInput()
+		return m.quitSession()
+
+	case tea.KeyEsc:
+		m.closeTimeInput()
+		return nil
+
+	case tea.KeyTab:
+		m.editTotal = !m.editTotal
+		m.timeInput.Prompt = "remaining: "
+		if m.editTotal {
+			m.timeInput.Prompt = "total: "
+		}
+		return nil
+
+	case tea.KeyEnter:
+		d, err := parseTime(m.timeInput.Value())
+		if err != nil {
+			return m.showBanner(err.Error(), true)
+		}
+
+		m.closeTimeInput()
+		if m.editTotal {
+			return m.setDuration(d)
+		}
+		return m.setDuration(m.elapsed + d)
+	}
+
+	var cmd tea.Cmd
+	m.timeInput, cmd = m.timeInput.Update(msg)
+
+	return cmd
+}
+
+// opens the inline input to rename the current session
+func (m *Model) openTitleInput() tea.Cmd {
+	m.editingTitle = true
+	m.titleInput.SetValue("")
+	m.titleInput.Placeholder = m.currentTask.Title
+	m.titleInput.SetSuggestions(m.recentTitles())
+
+	return m.titleInput.Focus()
+}
+
+func (m *Model) closeTitleInput() {
+	m.editingTitle = false
+	m.titleInput.Blur()
+}
+
+// the timer keeps running while the title is typed, an empty title keeps the current one
+func (m *Model) handleTitleInputKeys(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		m.closeTitleInput()
+		return m.quitSession()
+
+	case tea.KeyEsc:
+		m.closeTitleInput()
+		return nil
+
+	case tea.KeyEnter:
+		if title := strings.TrimSpace(m.titleInput.Value()); title != "" {
+			log.Printf("renaming session %q to %q", m.currentTask.Title, title)
+			m.currentTask.Title = title
+		}
+
+		m.closeTitleInput()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.titleInput, cmd = m.titleInput.Update(msg)
+
+	return cmd
+}
+
+// opens the inline input of the note of an interruption happening now
+func (m *Model) openNoteInput(kind db.InterruptionKind) tea.Cmd {
+	m.editingNote = true
+	m.interruption = db.Interruption{Kind: kind, At: time.Now()}
+	m.noteInput.Prompt = string(kind) + ": "
+	m.noteInput.SetValue("")
+
+	return m.noteInput.Focus()
+}
+
+func (m *Model) closeNoteInput() {
+	m.editingNote = false
+	m.noteInput.Blur()
+}
+
+// the timer keeps running while the note is typed,
+// the interruption is logged on enter with or without a note
+func (m *Model) handleNoteInputKeys(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		m.closeNoteInput()
+		return m.quitSession()
+
+	case tea.KeyEsc:
+		m.closeNoteInput()
+		return nil
+
+	case tea.KeyEnter:
+		m.interruption.Note = strings.TrimSpace(m.noteInput.Value())
+		m.interruptions = append(m.interruptions, m.interruption)
+		log.Printf("logged %v interruption %q", m.interruption.Kind, m.interruption.Note)
+
+		m.closeNoteInput()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.noteInput, cmd = m.noteInput.Update(msg)
+
+	return cmd
+}
+
+// opens the picker of the task of the work session,
+// returns false if there are no open tasks to pick
+func (m *Model) openTaskPicker() bool {
+	m.openTasks = m.getOpenTasks()
+	setTaskKey(len(m.openTasks) > 0)
+
+	if len(m.openTasks) == 0 {
+		return false
+	}
+
+	m.pickingTask = true
+	m.taskCursor = 0
+
+	for i, task := range m.openTasks {
+		if task.ID == m.task.ID {
+			m.task = task // with the latest pomodoros
+			m.taskCursor = i
+		}
+	}
+
+	return true
+}
+
+func (m *Model) closeTaskPicker() {
+	m.pickingTask = false
+}
+
+// the timer keeps running while the task is picked, esc keeps the current one
+func (m *Model) handleTaskPickerKeys(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c":
+		m.closeTaskPicker()
+		return m.quitSession()
+
+	case "esc":
+		m.closeTaskPicker()
+
+	case "up", "k", "shift+tab":
+		m.taskCursor = (m.taskCursor - 1 + len(m.openTasks)) % len(m.openTasks)
+
+	case "down", "j", "tab":
+		m.taskCursor = (m.taskCursor + 1) % len(m.openTasks)
+
+	case "enter":
+		m.task = m.openTasks[m.taskCursor]
+		m.currentTask.Title = m.task.Title
+		log.Printf("picked task %d %q", m.task.ID, m.task.Title)
+
+		m.closeTaskPicker()
+	}
+
+	return nil
+}
+
+// returns the open tasks for the task picker
+func (m *Model) getOpenTasks() []db.Task {
+	if m.taskRepo == nil {
+		return nil
+	}
+
+	tasks, err := m.taskRepo.GetOpenTasks()
+	if err != nil {
+		log.Printf("failed to get open tasks: %v", err)
+	}
+
+	return tasks
+}
+
+// returns the titles of the latest work sessions for the rename input
+func (m *Model) recentTitles() []string {
+	if m.repo == nil {
+		return nil
+	}
+
+	titles, err := m.repo.GetRecentTitles(recentTitlesLimit)
+	if err != nil {
+		log.Printf("failed to get recent titles: %v", err)
+	}
+
+	return titles
+}
+
+// parses a time typed in the input: a duration, e.g. 1h30m,
+// minutes, e.g. 15, or minutes and seconds, e.g. 12:30
+func parseTime(text string) (time.Duration, error) {
+	text = strings.TrimSpace(text)
+
+	var d time.Duration
+	var err error
+
+	if minutes, seconds, found := strings.Cut(text, ":"); found {
+		var mins, secs int
+		mins, err = strconv.Atoi(minutes)
+		if err == nil {
+			secs, err = strconv.Atoi(seconds)
+		}
+		if err == nil && (secs < 0 || secs >= 60) {
+			err = errors.New("seconds out of range")
+		}
+		// "-0:30" would be 30 seconds otherwise
+		if err == nil && strings.ContainsAny(text, "+-") {
+			err = errors.New("signed time")
+		}
+		d = time.Duration(mins)*time.Minute + time.Duration(secs)*time.Second
+	} else if minutes, convErr := strconv.Atoi(text); convErr == nil {
+		d = time.Duration(minutes) * time.Minute
+	} else {
+		d, err = time.ParseDuration(text)
+	}
+
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid time '%v', expected e.g. 10m, 1h30m or 12:30", text)
+	}
+
+	return d, nil
+}
+
+// sets the total duration of the session, keeping at least minRemaining left
+func (m *Model) setDuration(d time.Duration) tea.Cmd {
+	m.duration = max(d, m.elapsed+minRemaining)
+	return m.updateProgressBar()
+}
+
 func (m *Model) handleConfirmChoice(msg confirm.ChoiceMsg) tea.Cmd {
 	switch msg.Choice {
 	case confirm.Confirm:
-		return m.nextSession()
+		cmd := m.nextSession()
+
+		// work sessions start right away, their task can be picked
+		// or their title typed meanwhile
+		if m.currentTaskType == config.WorkTask {
+			if m.openTaskPicker() {
+				return cmd
+			}
+			return tea.Batch(cmd, m.openTitleInput())
+		}
+		return cmd
 	case confirm.ShortSession:
 		return m.shortSession()
 	case confirm.Cancel:
@@ -91,6 +507,7 @@ func (m *Model) handleWindowResize(msg tea.WindowSizeMsg) tea.Cmd {
 	m.width = msg.Width
 	m.height = msg.Height
 	m.progressBar.Width = min(m.width-2*padding-margin, maxWidth)
+	m.help.Width = m.width // truncated with an ellipsis on narrow windows
 
 	return nil
 }
@@ -127,9 +544,46 @@ func (m *Model) handleConfirmTick() tea.Cmd {
 	}
 
 	// send tick every second to update idle time
-	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+	tick := tea.Tick(time.Second, func(t time.Time) tea.Msg {
 		return confirmTickMsg{}
 	})
+
+	idle := time.Since(m.confirmStartTime)
+	if m.reminder.Interval > 0 && idle >= m.reminder.Interval*time.Duration(m.remindersSent+1) {
+		return tea.Batch(tick, m.sendReminder(idle))
+	}
+
+	return tick
+}
+
+// notifies again that the next session is waiting,
+// reminders become urgent once more than reminder.urgentAfter were sent
+func (m *Model) sendReminder(idle time.Duration) tea.Cmd {
+	m.remindersSent++
+	log.Printf("sending reminder %d after %v idle", m.remindersSent, idle.Truncate(time.Second))
+
+	notification := config.Notification{
+		Enabled: true,
+		Urgent:  m.reminder.UrgentAfter > 0 && m.remindersSent > m.reminder.UrgentAfter,
+		Title:   m.reminder.Title,
+		Message: m.reminder.Message,
+		Icon:    m.currentTask.Notification.Icon,
+	}
+
+	notifier := m.notifier()
+	session := m.actionContext()
+	session.Idle = idle
+	results := m.commandResults
+
+	return func() tea.Msg {
+		notifier.Send(context.Background(), notification, session, results)
+		return nil
+	}
+}
+
+// returns the notifier of the configured backends, the banner backend shows notices in the timer
+func (m *Model) notifier() actions.Notifier {
+	return actions.Notifier{Notifications: m.notifications, Banners: m.notices}
 }
 
 func (m *Model) handleTimerStartStop(msg timer.StartStopMsg) tea.Cmd {
@@ -140,7 +594,7 @@ func (m *Model) handleTimerStartStop(msg timer.StartStopMsg) tea.Cmd {
 }
 
 func (m *Model) handleProgressBarFrame(msg progress.FrameMsg) tea.Cmd {
-	if m.progressBar.Percent() >= 1.0 && !m.progressBar.IsAnimating() && m.sessionState == Running {
+	if m.progressBar.Percent() >= 1.0 && !m.progressBar.IsAnimating() && m.sessionState == Running && !m.countingUp() {
 		return m.handleCompletion()
 	}
 
@@ -171,17 +625,137 @@ func (m Model) getPercent() float64 {
 func (m *Model) handleCompletion() tea.Cmd {
 	log.Println("timer completed")
 
+	// in overtime, the session is recorded once it's finished,
+	// until then the actions count it as a pending session
+	var overtime tea.Cmd
+	if m.overtime {
+		overtime = m.startOvertime()
+	} else {
+		m.recordSession()
+	}
+
+	// each command has its own timeout
+	m.postActions = m.dispatch("complete")
+
+	if m.isLongBreak {
+		m.dispatch("cycleComplete")
+	}
+
+	if m.overtime {
+		return overtime
+	}
+
+	return m.continueAfterSession()
+}
+
+// records the current session and quits
+func (m *Model) quitSession() tea.Cmd {
+	if m.countingUp() {
+		m.endCountUp()
+	} else {
+		m.recordSession()
+	}
+
+	return m.Quit()
+}
+
+// keeps counting past zero until the session is finished, skipped or quit
+func (m *Model) startOvertime() tea.Cmd {
+	log.Println("overtime started")
+
+	m.inOvertime = true
+	setCountUpKeys(true)
+
+	return countUpTick(m.startedAt)
+}
+
+// records the session with its overtime
+func (m *Model) endOvertime() {
+	log.Printf("overtime ended after %v", (m.elapsed - m.duration).Truncate(time.Second))
+
 	m.recordSession()
+	m.inOvertime = false
+	setCountUpKeys(false)
+}
+
+// starts the open-ended work session of flow mode
+func (m *Model) startStopwatch() tea.Cmd {
+	log.Println("stopwatch started")
+
+	m.stopwatch = true
+	m.duration = 0
+	m.timer = timer.New(0)
+	setCountUpKeys(true)
+
+	return countUpTick(m.startedAt)
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), actions.CommandTimeout)
-	m.commandsCancel = cancel
-	m.commandsWg = actions.RunPostActions(ctx, m.currentTask)
+// records the flow work session and adds it to the work time of the next break
+func (m *Model) endStopwatch() {
+	log.Printf("stopwatch stopped after %v", m.elapsed.Truncate(time.Second))
+
+	m.recordSession()
+	m.worked += m.elapsed
+	m.stopwatch = false
+	setCountUpKeys(false)
+}
+
+// reports whether the timer counts up, in overtime or a flow session
+func (m Model) countingUp() bool {
+	return m.inOvertime || m.stopwatch
+}
+
+// ends overtime or the flow session
+func (m *Model) endCountUp() {
+	if m.stopwatch {
+		m.endStopwatch()
+	} else {
+		m.endOvertime()
+	}
+}
+
+// returns the break earned by the work time of the flow session,
+// it lasts at least minRemaining
+func (m Model) flowBreak() time.Duration {
+	worked := m.worked
+	if m.stopwatch {
+		worked += m.elapsed
+	}
+
+	return max(m.flow.BreakFor(worked), minRemaining)
+}
+
+func countUpTick(startedAt time.Time) tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return countUpTickMsg{startedAt: startedAt}
+	})
+}
+
+func (m *Model) handleCountUpTick(msg countUpTickMsg) tea.Cmd {
+	if !m.countingUp() || !msg.startedAt.Equal(m.startedAt) {
+		return nil
+	}
+
+	if m.sessionState != Paused {
+		m.elapsed += time.Second
+	}
+
+	return countUpTick(m.startedAt)
+}
+
+// continues after a finished session according to config
+func (m *Model) continueAfterSession() tea.Cmd {
+	// inputs left open belong to the finished session
+	m.closeTimeInput()
+	m.closeTitleInput()
+	m.closeNoteInput()
+	m.closeTaskPicker()
 
-	// continue after the completion according to config
 	switch m.onSessionEnd {
 	case "ask":
 		m.sessionState = ShowingConfirm
 		m.confirmStartTime = time.Now()
+		m.remindersSent = 0
 
 		// send first confirm tick
 		return func() tea.Msg {
@@ -207,7 +781,7 @@ func (m *Model) nextSession() tea.Cmd {
 		}
 
 		// start long break if cycle position reaches configured value after a work session
-		if m.currentTaskType == config.WorkTask && m.cyclePosition == m.longBreak.After {
+		if m.isLongBreakNext() {
 			return m.longBreakSession()
 		}
 
@@ -218,16 +792,28 @@ func (m *Model) nextSession() tea.Cmd {
 	}
 
 	nextTaskType := m.currentTaskType.Opposite()
-	return m.startSession(nextTaskType, *nextTaskType.GetTask(), false)
+	task := *nextTaskType.GetTask()
+
+	// work sessions continue the picked task
+	if nextTaskType == config.WorkTask && m.task.ID != 0 {
+		task.Title = m.task.Title
+	}
+
+	// flow breaks are earned by the work time
+	if m.isFlow && nextTaskType == config.BreakTask {
+		task.Duration = m.flowBreak()
+	}
+
+	return m.startSession(nextTaskType, task, false)
 }
 
 // starts a long break session
 func (m *Model) longBreakSession() tea.Cmd {
-	longBreak := *config.BreakTask.GetTask()
-	longBreak.Duration = m.longBreak.Duration
-	longBreak.Title = "long " + longBreak.Title
+	cmd := m.startSession(config.BreakTask, m.longBreak.Task, false)
+	m.isLongBreak = true
+	m.dispatch("longBreak")
 
-	return m.startSession(config.BreakTask, longBreak, false)
+	return cmd
 }
 
 // starts a short session of the current task type
@@ -246,22 +832,36 @@ func (m *Model) startSession(taskType config.TaskType, task config.Task, isShort
 	//
 	// for onSessionEnd == "start", we don't cancel immediately
 	// will run commands in the background with the context time limit
-	if m.commandsCancel != nil && m.onSessionEnd != "start" {
-		m.commandsCancel()
+	if m.postActions != nil && m.onSessionEnd != "start" {
+		m.postActions()
 	}
-
-	// clean up previous commands state
-	m.commandsWg, m.commandsCancel = nil, nil
+	m.postActions = nil
 
 	m.isShortSession = isShortSession
+	m.isLongBreak = false
 	m.currentTaskType = taskType
 	m.currentTask = task
 
 	m.elapsed = 0
+	m.startedAt = time.Now()
 	m.duration = m.currentTask.Duration
 	m.timer = timer.New(m.currentTask.Duration)
+	m.interruptions = nil
+	setInterruptionKeys(taskType == config.WorkTask)
+	setTaskKey(taskType == config.WorkTask && len(m.openTasks) > 0)
 
 	m.sessionState = Running
+	m.dispatch("start")
+
+	if m.isFlow && taskType == config.WorkTask {
+		// short sessions extend the work time of the last one
+		if !isShortSession {
+			m.worked = 0
+		}
+
+		return tea.Batch(m.progressBar.SetPercent(0.0), m.startStopwatch())
+	}
+
 	return tea.Batch(
 		m.progressBar.SetPercent(0.0),
 		m.timer.Start(),
@@ -270,78 +870,321 @@ func (m *Model) startSession(taskType config.TaskType, task config.Task, isShort
 
 // records the current session into the session summary
 func (m *Model) recordSession() {
-	// ignore very short or zero duration sessions
-	if m.elapsed < time.Second {
+	// ignore very short or zero duration sessions, unless they were interrupted
+	if m.elapsed < time.Second && len(m.interruptions) == 0 {
 		return
 	}
 
+	if m.inOvertime {
+		m.sessionSummary.AddOvertime(m.elapsed - m.duration)
+	}
+
 	// short sessions extend the current session without incrementing the count
 	if m.isShortSession {
 		m.sessionSummary.AddDuration(m.currentTaskType, m.elapsed)
+		m.recordShortInterruptions()
 		return
 	}
 
+	session := db.Session{
+		Type:      db.GetSessionType(m.currentTaskType),
+		Title:     m.currentTask.Title,
+		Duration:  m.elapsed,
+		Planned:   m.duration,
+		StartedAt: time.Now(),
+
+		Interruptions: m.interruptions,
+	}
+
 	m.sessionSummary.AddSession(m.currentTaskType, m.elapsed)
+	if session.Type == db.WorkSession && session.Completed() {
+		m.sessionSummary.CompleteWorkSession()
+	}
 
 	// return if no database is configured
 	if m.repo == nil {
 		return
 	}
 
-	if err := m.repo.CreateSession(
-		time.Now(),
-		m.elapsed,
-		db.GetSessionType(m.currentTaskType),
-	); err != nil {
+	// only work sessions count as pomodoros of the task
+	if session.Type == db.WorkSession {
+		session.TaskID = m.task.ID
+	}
+
+	if err := m.repo.CreateSession(session); err != nil {
 		log.Printf("failed to record session: %v", err)
+		return
+	}
+
+	// sessions that ended early stay linked without counting as a pomodoro
+	if session.TaskID != 0 && session.Completed() {
+		m.task.Pomodoros++
+		m.sessionSummary.SetTask(m.task.ID, m.task.Title, m.task.Pomodoros, m.task.Estimate)
 	}
 }
 
+// saves the interruptions of a short session under the work session it extends
+func (m *Model) recordShortInterruptions() {
+	if m.repo == nil || len(m.interruptions) == 0 {
+		return
+	}
+
+	if err := m.repo.AddInterruptions(m.interruptions); err != nil {
+		log.Printf("failed to record interruptions: %v", err)
+	}
+}
+
+// applies a reloaded config without disturbing the current countdown,
+// the new durations and titles take effect from the next session
+func (m *Model) handleConfigReload(msg ConfigReloadedMsg) tea.Cmd {
+	if msg.Err != nil {
+		log.Println("failed to reload config:", msg.Err)
+		return m.showBanner("config error: "+msg.Err.Error(), true)
+	}
+
+	if err := ApplyKeys(msg.Config.Keys); err != nil {
+		log.Println("failed to reload key bindings:", err)
+		return m.showBanner("config error: "+err.Error(), true)
+	}
+
+	theme, err := msg.Config.Theme.Build()
+	if err != nil {
+		log.Println("failed to reload theme:", err)
+		return m.showBanner("config error: "+err.Error(), true)
+	}
+
+	registered, err := actions.FromConfig(msg.Config)
+	if err != nil {
+		log.Println("failed to reload actions:", err)
+		return m.showBanner("config error: "+err.Error(), true)
+	}
+
+	config.C = msg.Config
+	colors.Current = theme
+	m.actionRunner.SetActions(registered)
+
+	m.onSessionEnd = msg.Config.OnSessionEnd
+	m.longBreak = msg.Config.LongBreak
+	m.longBreak.Enabled = m.longBreak.Enabled && !m.isFlow
+	m.dailyGoal = msg.Config.DailyGoal
+	m.step = msg.Config.Timer.Step
+	setStepHelp(m.step)
+	setCountUpKeys(m.countingUp())
+	setInterruptionKeys(m.currentTaskType == config.WorkTask)
+	setTaskKey(m.currentTaskType == config.WorkTask && len(m.openTasks) > 0)
+	m.overtime = msg.Config.Timer.Overtime
+	m.flow = msg.Config.Flow
+	m.notifications = msg.Config.Notifications
+	m.reminder = msg.Config.Reminder
+	m.setASCIIArt(msg.Config.ASCIIArt)
+	themeGradient()(&m.progressBar)
+
+	// the current session keeps its title and duration
+	task := m.currentTaskType.From(&msg.Config)
+	if m.isLongBreak {
+		task = &msg.Config.LongBreak.Task
+	}
+	m.currentTask.Notification = task.Notification
+	m.currentTask.Then = task.Then
+
+	log.Println("config reloaded")
+	return m.showBanner("config reloaded", false)
+}
+
+// shows a message below the timer for a few seconds
+func (m *Model) showBanner(text string, isError bool) tea.Cmd {
+	id := m.banner.id + 1
+	m.banner = banner{id: id, text: text, isError: isError}
+
+	return tea.Tick(bannerTimeout, func(t time.Time) tea.Msg {
+		return bannerTimeoutMsg{id: id}
+	})
+}
+
+// shows a flashing message below the timer, or the confirm dialog, for a while
+func (m *Model) flashBanner(text string, isError bool) tea.Cmd {
+	id := m.banner.id + 1
+	m.banner = banner{id: id, text: text, isError: isError, flashing: true, highlight: true}
+
+	return tea.Batch(
+		tea.Tick(noticeTimeout, func(t time.Time) tea.Msg {
+			return bannerTimeoutMsg{id: id}
+		}),
+		flashTick(id),
+	)
+}
+
+func flashTick(id int) tea.Cmd {
+	return tea.Tick(flashInterval, func(t time.Time) tea.Msg {
+		return bannerFlashMsg{id: id}
+	})
+}
+
+func (m *Model) handleBannerFlash(msg bannerFlashMsg) tea.Cmd {
+	// the banner was replaced or cleared
+	if msg.id != m.banner.id || m.banner.text == "" {
+		return nil
+	}
+
+	m.banner.highlight = !m.banner.highlight
+	return flashTick(msg.id)
+}
+
+func (m *Model) handleBannerTimeout(msg bannerTimeoutMsg) tea.Cmd {
+	// a newer banner is showing
+	if msg.id != m.banner.id {
+		return nil
+	}
+
+	m.banner.text = ""
+	return nil
+}
+
+// waits for the next post command or hook result
+func (m *Model) waitForCommandResult() tea.Cmd {
+	results := m.commandResults
+
+	return func() tea.Msg {
+		return commandResultMsg{result: <-results}
+	}
+}
+
+// shows failed commands in a banner and keeps them for the session summary
+func (m *Model) handleCommandResult(msg commandResultMsg) tea.Cmd {
+	next := m.waitForCommandResult()
+
+	if !msg.result.Failed() {
+		return next
+	}
+
+	summary := msg.result.Summary()
+	m.sessionSummary.AddFailedCommand(msg.result.Source, summary)
+
+	return tea.Batch(next, m.showBanner("command failed: "+summary, true))
+}
+
+// waits for the next notification of the banner backend
+func (m *Model) waitForNotice() tea.Cmd {
+	notices := m.notices
+
+	return func() tea.Msg {
+		return noticeMsg{notice: <-notices}
+	}
+}
+
+func (m *Model) handleNotice(msg noticeMsg) tea.Cmd {
+	text := msg.notice.Title
+	if msg.notice.Message != "" {
+		text += " · " + msg.notice.Message
+	}
+
+	return tea.Batch(m.waitForNotice(), m.flashBanner(text, msg.notice.Urgent))
+}
+
+// runs the actions of the event in the background with the current session context,
+// returns the function that cancels them
+func (m *Model) dispatch(event string) context.CancelFunc {
+	// skip the session queries if no action runs on the event
+	if !m.actionRunner.Wants(event) {
+		return func() {}
+	}
+
+	return m.actionRunner.Dispatch(actions.Event{
+		Name:    event,
+		Task:    m.currentTask,
+		Session: m.actionContext(),
+	})
+}
+
+// returns the current session details for actions
+func (m *Model) actionContext() actions.Context {
+	todayTotal := m.sessionSummary.WorkDuration()
+	todayCount := m.sessionSummary.CompletedWorkSessions()
+
+	if m.repo != nil {
+		if total, err := m.repo.GetWorkDuration(time.Now()); err == nil {
+			todayTotal = total
+		} else {
+			log.Println("failed to get today's work duration:", err)
+		}
+
+		if count, err := m.repo.GetWorkCount(time.Now()); err == nil {
+			todayCount = count
+		} else {
+			log.Println("failed to get today's work sessions:", err)
+		}
+	}
+
+	// the completed session isn't recorded until its overtime ends
+	if m.inOvertime && m.currentTaskType == config.WorkTask && !m.isShortSession {
+		todayTotal += m.elapsed
+		todayCount++
+	}
+
+	cycleLength := 0
+	if m.longBreak.Enabled {
+		cycleLength = m.longBreak.After
+	}
+
+	return actions.Context{
+		TaskType:      string(db.GetSessionType(m.currentTaskType)),
+		Title:         m.currentTask.Title,
+		Elapsed:       m.elapsed,
+		Planned:       m.duration,
+		Cycle:         m.cyclePosition,
+		CycleLength:   cycleLength,
+		IsLongBreak:   m.isLongBreak,
+		LongBreakNext: m.isLongBreakNext(),
+		TodayTotal:    todayTotal,
+		TodayCount:    todayCount,
+		Goal:          m.dailyGoal,
+		StartedAt:     m.startedAt,
+	}
+}
+
+// reports whether a long break follows the current session
+func (m *Model) isLongBreakNext() bool {
+	return m.longBreak.Enabled && m.currentTaskType == config.WorkTask && m.cyclePosition == m.longBreak.After
+}
+
 // handles the completion of post actions and quits the application
 func (m *Model) handleCommandsDone() tea.Cmd {
 	m.sessionState = Quitting
 	return tea.Quit
 }
 
-// waits for any running post actions to complete before quitting the application
+// waits for any running actions to complete before quitting the application
 func (m *Model) waitForCommands() tea.Cmd {
 	m.sessionState = WaitingForCommands
+	runner := m.actionRunner
 
 	return func() tea.Msg {
-		if m.commandsWg != nil {
-			log.Println("waiting for post actions to complete...")
-			m.commandsWg.Wait()
-			log.Println("post actions completed")
-		}
-
-		// cancel any running commands
-		// in case they are still running after the wait
-		if m.commandsCancel != nil {
-			m.commandsCancel()
-		}
+		log.Println("waiting for actions to complete...")
+		runner.Wait()
+		log.Println("actions completed")
 
 		return commandsDoneMsg{}
 	}
 }
 
 // Quit handles quitting the application
-// ensuring that any running post actions are completed before exiting
+// ensuring that any running actions are completed before exiting
 func (m *Model) Quit() tea.Cmd {
 	// if we're already waiting for commands to finish, force quit
 	if m.sessionState == WaitingForCommands {
 		log.Println("force quitting...")
 
-		// cancel any running commands
-		if m.commandsCancel != nil {
-			m.commandsCancel()
-		}
+		// cancel any running actions
+		m.actionRunner.Cancel()
 
 		m.sessionState = Quitting
 		return tea.Quit
 	}
 
-	// wait for any running post actions to complete before quitting
-	if m.commandsWg != nil {
+	m.dispatch("quit")
+
+	// wait for any running actions to complete before quitting
+	if m.actionRunner.Running() {
 		return m.waitForCommands()
 	}
 
@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Bahaaio/pomo/config"
+	"github.com/Bahaaio/pomo/db"
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// returns a model recording into a temporary database
+func newTestModel(t *testing.T) Model {
+	database, err := db.Open(filepath.Join(t.TempDir(), db.DBFile))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+
+	return Model{
+		currentTaskType: config.WorkTask,
+		repo:            db.NewSessionRepo(database),
+	}
+}
+
+func TestRecordShortSessionInterruptions(t *testing.T) {
+	m := newTestModel(t)
+	now := time.Now()
+
+	m.duration = 25 * time.Minute
+	m.elapsed = 25 * time.Minute
+	m.recordSession()
+
+	// a short session extending the work session
+	m.isShortSession = true
+	m.duration = 5 * time.Minute
+	m.elapsed = 3 * time.Minute
+	m.interruptions = []db.Interruption{{Kind: db.ExternalInterruption, Note: "phone call", At: now}}
+	m.recordSession()
+
+	stats, err := m.repo.GetWeeklyInterruptions()
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats[len(stats)-1].External, "interruptions of short sessions should be recorded")
+
+	sessions, err := m.repo.GetSessions(now, now)
+	require.NoError(t, err)
+	assert.Len(t, sessions, 1, "short sessions extend the work session")
+}
+
+func TestParseTime(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"duration", "10m", 10 * time.Minute, false},
+		{"hours and minutes", "1h30m", 90 * time.Minute, false},
+		{"minutes and seconds", "12:30", 12*time.Minute + 30*time.Second, false},
+		{"seconds only", "0:45", 45 * time.Second, false},
+		{"bare minutes", "15", 15 * time.Minute, false},
+		{"surrounding spaces", " 5m ", 5 * time.Minute, false},
+		{"zero", "0:00", 0, true},
+		{"zero minutes", "0", 0, true},
+		{"negative seconds", "-0:30", 0, true},
+		{"negative minutes", "-5", 0, true},
+		{"negative duration", "-10m", 0, true},
+		{"seconds out of range", "1:60", 0, true},
+		{"missing seconds", "1:", 0, true},
+		{"empty", "", 0, true},
+		{"text", "soon", 0, true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := parseTime(tt.text)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, d)
+		})
+	}
+}
+
+func TestSetTime(t *testing.T) {
+	testCases := []struct {
+		name     string
+		total    bool // tab switches the input to the total duration
+		text     string
+		expected time.Duration
+	}{
+		{"remaining", false, "10m", 20 * time.Minute},
+		{"total", true, "30m", 30 * time.Minute},
+		{"total below elapsed", true, "5m", 10*time.Minute + minRemaining},
+		{"total at elapsed", true, "10:00", 10*time.Minute + minRemaining},
+		{"short remaining", false, "0:05", 10*time.Minute + minRemaining},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{progressBar: progress.New(), timeInput: newTimeInput()}
+			m.duration = 25 * time.Minute
+			m.elapsed = 10 * time.Minute
+
+			m.openTimeInput()
+			if tt.total {
+				m.handleTimeInputKeys(tea.KeyMsg{Type: tea.KeyTab})
+			}
+			m.timeInput.SetValue(tt.text)
+			m.handleTimeInputKeys(tea.KeyMsg{Type: tea.KeyEnter})
+
+			assert.False(t, m.editingTime)
+			assert.Equal(t, tt.expected, m.duration)
+			assert.Equal(t, tt.expected-m.elapsed, m.timer.Timeout)
+		})
+	}
+}
+
+func TestSetTimeInvalid(t *testing.T) {
+	m := Model{progressBar: progress.New(), timeInput: newTimeInput()}
+	m.duration = 25 * time.Minute
+
+	m.openTimeInput()
+	m.timeInput.SetValue("1:60")
+	m.handleTimeInputKeys(tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.True(t, m.editingTime, "the input should stay open to fix the time")
+	assert.True(t, m.banner.isError)
+	assert.Equal(t, 25*time.Minute, m.duration)
+}
+
+func TestFormatStep(t *testing.T) {
+	testCases := []struct {
+		step     time.Duration
+		expected string
+	}{
+		{time.Minute, "1m"},
+		{5 * time.Minute, "5m"},
+		{30 * time.Second, "30s"},
+		{90 * time.Second, "1m30s"},
+		{time.Hour, "1h"},
+		{90 * time.Minute, "1h30m"},
+		{time.Hour + 30*time.Second, "1h0m30s"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatStep(tt.step))
+		})
+	}
+}
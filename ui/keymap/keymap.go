@@ -0,0 +1,111 @@
+// Package keymap remaps key bindings from the config.
+package keymap
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// friendlier names for keys in the config and help text
+var (
+	aliases = map[string]string{
+		"space": " ",
+	}
+
+	labels = map[string]string{
+		" ":     "space",
+		"up":    "↑",
+		"down":  "↓",
+		"left":  "←",
+		"right": "→",
+	}
+)
+
+// Remap replaces the keys of the bindings in keyMap,
+// a pointer to a struct of [key.Binding] fields, with the ones in remap.
+// remap is keyed by action, the case-insensitive field name.
+// an empty list of keys disables the action.
+//
+// returns an error for unknown actions or keys bound to more than one action.
+func Remap(screen string, keyMap any, remap map[string][]string) error {
+	v := reflect.ValueOf(keyMap).Elem()
+
+	bindings := make(map[string]*key.Binding)
+	var actions []string
+
+	for i := range v.NumField() {
+		binding, ok := v.Field(i).Addr().Interface().(*key.Binding)
+		if !ok {
+			continue
+		}
+
+		action := strings.ToLower(v.Type().Field(i).Name)
+		bindings[action] = binding
+		actions = append(actions, action)
+	}
+
+	for action, keys := range remap {
+		binding, exists := bindings[strings.ToLower(action)]
+		if !exists {
+			return fmt.Errorf("unknown %v action '%v', expected one of: %v", screen, action, strings.Join(actions, ", "))
+		}
+
+		keys = normalize(keys)
+
+		binding.SetKeys(keys...)
+		binding.SetHelp(Label(keys), binding.Help().Desc)
+	}
+
+	return checkConflicts(screen, actions, bindings)
+}
+
+// Label returns the help text for a list of keys, e.g. "k/↑"
+func Label(keys []string) string {
+	formatted := make([]string, len(keys))
+
+	for i, k := range keys {
+		if label, exists := labels[k]; exists {
+			k = label
+		}
+		formatted[i] = k
+	}
+
+	return strings.Join(formatted, "/")
+}
+
+// resolves aliases and removes duplicate keys
+func normalize(keys []string) []string {
+	var normalized []string
+
+	for _, k := range keys {
+		if alias, exists := aliases[strings.ToLower(k)]; exists {
+			k = alias
+		}
+
+		if !slices.Contains(normalized, k) {
+			normalized = append(normalized, k)
+		}
+	}
+
+	return normalized
+}
+
+// returns an error if a key is bound to more than one action
+func checkConflicts(screen string, actions []string, bindings map[string]*key.Binding) error {
+	owners := make(map[string]string)
+
+	for _, action := range actions {
+		for _, k := range bindings[action].Keys() {
+			if owner, exists := owners[k]; exists {
+				return fmt.Errorf("%v key '%v' is bound to both '%v' and '%v'", screen, Label([]string{k}), owner, action)
+			}
+			owners[k] = action
+		}
+	}
+
+	return nil
+}
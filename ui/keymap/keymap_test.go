@@ -0,0 +1,70 @@
+package keymap_test
+
+import (
+	"testing"
+
+	"github.com/Bahaaio/pomo/ui/keymap"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/stretchr/testify/assert"
+)
+
+type testKeyMap struct {
+	Pause        key.Binding
+	Quit         key.Binding
+	ShortSession key.Binding
+}
+
+func newTestKeyMap() testKeyMap {
+	return testKeyMap{
+		Pause:        key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "pause")),
+		Quit:         key.NewBinding(key.WithKeys("ctrl+c", "q"), key.WithHelp("q", "quit")),
+		ShortSession: key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "short session")),
+	}
+}
+
+func TestRemap(t *testing.T) {
+	keys := newTestKeyMap()
+
+	err := keymap.Remap("test", &keys, map[string][]string{
+		"pause":        {"p", "space"},
+		"shortsession": {"up"},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"p", " "}, keys.Pause.Keys())
+	assert.Equal(t, "p/space", keys.Pause.Help().Key, "help should show the new keys")
+	assert.Equal(t, "pause", keys.Pause.Help().Desc, "help description should be kept")
+
+	assert.Equal(t, []string{"up"}, keys.ShortSession.Keys())
+	assert.Equal(t, "↑", keys.ShortSession.Help().Key)
+
+	// untouched actions keep their keys
+	assert.Equal(t, []string{"ctrl+c", "q"}, keys.Quit.Keys())
+}
+
+func TestRemapDisable(t *testing.T) {
+	keys := newTestKeyMap()
+
+	err := keymap.Remap("test", &keys, map[string][]string{"pause": {}})
+	assert.NoError(t, err)
+	assert.False(t, keys.Pause.Enabled(), "empty keys should disable the action")
+}
+
+func TestRemapErrors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		remap map[string][]string
+	}{
+		{"unknown action", map[string][]string{"explode": {"x"}}},
+		{"conflict with default", map[string][]string{"pause": {"q"}}},
+		{"conflict between remapped", map[string][]string{"pause": {"x"}, "shortsession": {"x"}}},
+		{"conflict with alias", map[string][]string{"quit": {"space"}}},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := newTestKeyMap()
+			assert.Error(t, keymap.Remap("test", &keys, tt.remap))
+		})
+	}
+}
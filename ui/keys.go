@@ -1,35 +1,96 @@
 package ui
 
 import (
+	"strings"
+	"time"
+
+	"github.com/Bahaaio/pomo/config"
+	"github.com/Bahaaio/pomo/ui/confirm"
+	"github.com/Bahaaio/pomo/ui/keymap"
 	"github.com/charmbracelet/bubbles/key"
 )
 
 type KeyMap struct {
 	Increase key.Binding
+	Decrease key.Binding
+	SetTime  key.Binding
+	Rename   key.Binding
+	Task     key.Binding // picks the task of the work session
+	Internal key.Binding // logs an internal interruption
+	External key.Binding // logs an external interruption
 	Reset    key.Binding
 	Pause    key.Binding
 	Skip     key.Binding
+	Finish   key.Binding // ends overtime or a flow session
 	Quit     key.Binding
 }
 
 func (k KeyMap) ShortHelp() []key.Binding {
 	return []key.Binding{
 		k.Increase,
+		k.Decrease,
+		k.SetTime,
+		k.Rename,
+		k.Task,
+		k.interruptionHelp(),
 		k.Pause,
 		k.Reset,
 		k.Skip,
+		k.Finish,
 		k.Quit,
 	}
 }
 
+// shows both interruption keys as one entry, e.g. "i/e interrupt"
+func (k KeyMap) interruptionHelp() key.Binding {
+	if !k.External.Enabled() {
+		return k.Internal
+	}
+
+	if !k.Internal.Enabled() {
+		return k.External
+	}
+
+	return key.NewBinding(
+		key.WithKeys(append(k.Internal.Keys(), k.External.Keys()...)...),
+		key.WithHelp(k.Internal.Help().Key+"/"+k.External.Help().Key, "interrupt"),
+	)
+}
+
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{}
 }
 
-var keyMap = KeyMap{
+var keyMap = defaultKeyMap
+
+var defaultKeyMap = KeyMap{
 	Increase: key.NewBinding(
 		key.WithKeys("k", "up"),
-		key.WithHelp("↑", "+1 minute"),
+		key.WithHelp("↑", "+1m"),
+	),
+	Decrease: key.NewBinding(
+		key.WithKeys("j", "down"),
+		key.WithHelp("↓", "-1m"),
+	),
+	SetTime: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "set time"),
+	),
+	Rename: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "rename"),
+	),
+	Task: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "task"),
+	),
+	Internal: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "internal"),
+	),
+	External: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "external"),
 	),
 	Reset: key.NewBinding(
 		key.WithKeys("h", "left"),
@@ -43,8 +104,75 @@ var keyMap = KeyMap{
 		key.WithKeys("s"),
 		key.WithHelp("s", "skip"),
 	),
+	Finish: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "finish"),
+		key.WithDisabled(),
+	),
 	Quit: key.NewBinding(
 		key.WithKeys("ctrl+c", "q"),
 		key.WithHelp("q", "quit"),
 	),
 }
+
+// enables the finish key while counting up, in overtime or a flow session,
+// and disables the keys that change the duration
+func setCountUpKeys(countingUp bool) {
+	keyMap.Finish.SetEnabled(countingUp)
+
+	keyMap.Increase.SetEnabled(!countingUp)
+	keyMap.Decrease.SetEnabled(!countingUp)
+	keyMap.SetTime.SetEnabled(!countingUp)
+	keyMap.Reset.SetEnabled(!countingUp)
+}
+
+// enables the interruption keys, interruptions are only logged during work sessions
+func setInterruptionKeys(enabled bool) {
+	keyMap.Internal.SetEnabled(enabled)
+	keyMap.External.SetEnabled(enabled)
+}
+
+// enables the task key, while there are open tasks to pick during a work session
+func setTaskKey(enabled bool) {
+	keyMap.Task.SetEnabled(enabled)
+}
+
+// shows the step of the increase and decrease keys in their help
+func setStepHelp(step time.Duration) {
+	keyMap.Increase.SetHelp(keyMap.Increase.Help().Key, "+"+formatStep(step))
+	keyMap.Decrease.SetHelp(keyMap.Decrease.Help().Key, "-"+formatStep(step))
+}
+
+// formats the step without zero units, e.g. 1m0s -> 1m
+func formatStep(step time.Duration) string {
+	text := step.String()
+	text = strings.TrimSuffix(text, "m0s")
+	if text != step.String() {
+		text += "m"
+	}
+
+	if trimmed := strings.TrimSuffix(text, "h0m"); trimmed != text {
+		text = trimmed + "h"
+	}
+
+	return text
+}
+
+// ApplyKeys remaps the timer and confirm dialog key bindings,
+// keeping the current ones if any of them is invalid.
+func ApplyKeys(keys config.Keys) error {
+	timerKeys := defaultKeyMap
+	if err := keymap.Remap("timer", &timerKeys, keys.Timer); err != nil {
+		return err
+	}
+
+	confirmKeys := confirm.DefaultKeys
+	if err := keymap.Remap("confirm", &confirmKeys, keys.Confirm); err != nil {
+		return err
+	}
+
+	keyMap = timerKeys
+	confirm.Keys = confirmKeys
+
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Bahaaio/pomo/config"
+	"github.com/Bahaaio/pomo/db"
 	"github.com/Bahaaio/pomo/ui/ascii"
 	"github.com/Bahaaio/pomo/ui/colors"
 	"github.com/charmbracelet/lipgloss"
@@ -16,18 +18,130 @@ const (
 	separator          = " — "
 	pausedIndicator    = "(paused)"
 	completedIndicator = "done!"
+
+	// narrower progress bars are left out of the inline display
+	minInlineBarWidth = 10
 )
 
 func (m *Model) buildConfirmDialogView() string {
 	idle := time.Since(m.confirmStartTime).Truncate(time.Second)
+
+	return m.confirmDialog.View(m.confirmPrompt(), time.Duration(idle), m.buildBanner())
+}
+
+// returns the prompt to start the next session
+func (m *Model) confirmPrompt() string {
 	title := m.currentTaskType.Opposite().GetTask().Title
 
 	// if we're prompting to start a long break
-	if m.cyclePosition == m.longBreak.After {
-		title = "long " + title
+	if m.isLongBreakNext() {
+		title = m.longBreak.Title
 	}
 
-	return m.confirmDialog.View("start "+title+"?", time.Duration(idle))
+	if m.isFlow && m.currentTaskType == config.WorkTask {
+		title += " (" + formatStep(m.flowBreak()) + ")"
+	}
+
+	return "start " + title + "?"
+}
+
+// returns the compact timer of the inline display, the session and its progress on one line,
+// followed by the open input, task picker or banner on a second one
+func (m *Model) buildInlineView() string {
+	var line, below string
+
+	switch m.sessionState {
+	case WaitingForCommands:
+		line = "Waiting for post commands to complete..." +
+			lipgloss.NewStyle().Foreground(colors.Current.Dim).Render(" (q quit)")
+
+	case ShowingConfirm:
+		idle := time.Since(m.confirmStartTime).Truncate(time.Second)
+		line = m.confirmDialog.InlineView(m.confirmPrompt(), idle)
+		below = m.buildBanner()
+
+	default:
+		below = m.buildInlineBelow()
+
+		// the progress bar gives way to the folded second line
+		reserved := 0
+		if m.height == 1 && below != "" {
+			reserved = lipgloss.Width(" · " + below)
+		}
+		line = m.buildInlineTimer(reserved)
+	}
+
+	// a single row has no room for the second line, it's folded into the first
+	if m.height == 1 && below != "" {
+		line += " · " + below
+		below = ""
+	}
+
+	view := line
+	if below != "" {
+		view += "\n" + below
+	}
+
+	// wrapped lines would break the rendering in place
+	if m.width > 0 {
+		view = lipgloss.NewStyle().MaxWidth(m.width).Render(view)
+	}
+
+	return view
+}
+
+// returns the title, time left, progress and status of the session on a single line,
+// leaving reserved columns for the rest of the line
+func (m *Model) buildInlineTimer(reserved int) string {
+	content := m.buildMainContent()
+	indicators := m.buildStatusIndicators()
+
+	// flow sessions have no end, show the break earned so far instead
+	if m.stopwatch {
+		earned := lipgloss.NewStyle().Foreground(colors.Current.Dim).Render("break: " + formatStep(m.flowBreak()))
+		return content + indicators + " · " + earned
+	}
+
+	barWidth := min(m.width-lipgloss.Width(content+indicators)-reserved-2, maxWidth)
+	if barWidth < minInlineBarWidth {
+		return content + indicators
+	}
+
+	bar := m.progressBar
+	bar.Width = barWidth
+
+	return content + " " + bar.View() + indicators
+}
+
+// returns the open input or task picker with short hints, or the banner
+func (m *Model) buildInlineBelow() string {
+	dim := lipgloss.NewStyle().Foreground(colors.Current.Dim)
+
+	switch {
+	case m.editingTime:
+		return m.timeInput.View() + dim.Render(" tab remaining/total • enter set • esc cancel")
+
+	case m.editingTitle:
+		return m.titleInput.View() + dim.Render(" tab complete • enter rename • esc keep")
+
+	case m.editingNote:
+		return m.noteInput.View() + dim.Render(" enter log • esc cancel")
+
+	case m.pickingTask:
+		task := m.openTasks[m.taskCursor]
+
+		row := task.Title
+		if task.Pomodoros > 0 || task.Estimate > 0 {
+			row += " " + formatPomodoros(task)
+		}
+
+		position := fmt.Sprintf(" (%d/%d)", m.taskCursor+1, len(m.openTasks))
+		selected := lipgloss.NewStyle().Foreground(colors.Current.Timer).Bold(true)
+
+		return "task: " + selected.Render(row) + dim.Render(position+" ↑/↓ select • enter pick • esc keep")
+	}
+
+	return m.buildBanner()
 }
 
 func (m *Model) buildMainContent() string {
@@ -38,7 +152,7 @@ func (m *Model) buildMainContent() string {
 	}
 
 	content := m.currentTask.Title
-	if !m.timer.Timedout() {
+	if !m.timer.Timedout() || m.countingUp() {
 		content += separator + timeLeft
 	}
 
@@ -46,7 +160,7 @@ func (m *Model) buildMainContent() string {
 }
 
 func (m *Model) buildStatusIndicators() string {
-	if m.timer.Timedout() {
+	if m.timer.Timedout() && !m.countingUp() {
 		return separator + completedIndicator
 	}
 
@@ -56,6 +170,18 @@ func (m *Model) buildStatusIndicators() string {
 		indicators += fmt.Sprintf(" · %d/%d", m.cyclePosition, m.longBreak.After)
 	}
 
+	if m.currentTaskType == config.WorkTask && (m.task.Pomodoros > 0 || m.task.Estimate > 0) {
+		indicators += " · " + formatPomodoros(m.task)
+	}
+
+	switch count := len(m.interruptions); count {
+	case 0:
+	case 1:
+		indicators += " · 1 interruption"
+	default:
+		indicators += fmt.Sprintf(" · %d interruptions", count)
+	}
+
 	if m.sessionState == Paused {
 		indicators += " " + pausedIndicator
 	}
@@ -64,12 +190,23 @@ func (m *Model) buildStatusIndicators() string {
 }
 
 func (m *Model) buildProgressBar() string {
+	// flow sessions have no end, show the break earned so far instead
+	if m.stopwatch {
+		earned := lipgloss.NewStyle().Foreground(colors.Current.Dim).Render("break: " + formatStep(m.flowBreak()))
+		return "\n\n" + earned + "\n"
+	}
+
 	return "\n\n" + m.progressBar.View() + "\n"
 }
 
-// returns time left as a string in HH:MM:SS format
+// returns time left as a string in HH:MM:SS format,
+// the time past zero as +HH:MM:SS in overtime, or the elapsed time of a flow session
 func (m *Model) buildTimeLeft() string {
 	left := m.timer.Timeout
+	if m.countingUp() {
+		left = m.elapsed - m.duration
+	}
+
 	hours := int(left.Hours())
 	minutes := int(left.Minutes()) % 60
 	seconds := int(left.Seconds()) % 60
@@ -82,25 +219,138 @@ func (m *Model) buildTimeLeft() string {
 	}
 	time += fmt.Sprintf("%02d:%02d", minutes, seconds)
 
+	if m.inOvertime {
+		time = "+" + time
+	}
+
 	if m.useTimerArt {
 		time = ascii.RenderNumber(time, m.timerFont)
 
 		// remove color on pause
 		if m.sessionState == Paused {
-			noColor := m.asciiTimerStyle.Foreground(colors.PauseFg)
+			noColor := m.asciiTimerStyle.Foreground(colors.Current.Pause)
 			return noColor.Render(time)
 		}
 
+		if m.inOvertime {
+			return m.asciiTimerStyle.Foreground(colors.Current.Overtime).Render(time)
+		}
+
+		if m.isLongBreak && m.longBreak.Color != "" {
+			return m.asciiTimerStyle.Foreground(colors.GetColor(m.longBreak.Color)).Render(time)
+		}
+
 		return m.asciiTimerStyle.Render(time)
 	}
 
+	if m.inOvertime {
+		return lipgloss.NewStyle().Foreground(colors.Current.Overtime).Render(time)
+	}
+
 	return time
 }
 
+// returns the banner message, or an empty string if there is none
+func (m *Model) buildBanner() string {
+	if m.banner.text == "" {
+		return ""
+	}
+
+	style := lipgloss.NewStyle().Foreground(colors.Current.SuccessMessage)
+	if m.banner.isError {
+		style = style.Foreground(colors.Current.ErrorMessage)
+	}
+
+	if m.banner.flashing {
+		style = style.Bold(true).Padding(0, 1).Reverse(m.banner.highlight)
+	}
+
+	return style.Render(m.banner.text)
+}
+
 func (m *Model) buildHelpView() string {
+	if m.editingTime {
+		return m.buildTimeInput()
+	}
+
+	if m.editingTitle {
+		return m.buildTitleInput()
+	}
+
+	if m.editingNote {
+		return m.buildNoteInput()
+	}
+
+	if m.pickingTask {
+		return m.buildTaskPicker()
+	}
+
 	return m.help.View(keyMap)
 }
 
+// returns the inline input of the set time key with its hints
+func (m *Model) buildTimeInput() string {
+	hint := lipgloss.NewStyle().Foreground(colors.Current.Dim).
+		Render("tab remaining/total • enter set • esc cancel")
+
+	return lipgloss.JoinVertical(lipgloss.Center, m.timeInput.View(), hint)
+}
+
+// returns the inline input of the rename key with its hints
+func (m *Model) buildTitleInput() string {
+	hint := lipgloss.NewStyle().Foreground(colors.Current.Dim).
+		Render("tab complete • ↑/↓ cycle • enter rename • esc keep")
+
+	return lipgloss.JoinVertical(lipgloss.Center, m.titleInput.View(), hint)
+}
+
+// returns the inline input of the note of an interruption with its hints
+func (m *Model) buildNoteInput() string {
+	hint := lipgloss.NewStyle().Foreground(colors.Current.Dim).
+		Render("enter log • esc cancel")
+
+	return lipgloss.JoinVertical(lipgloss.Center, m.noteInput.View(), hint)
+}
+
+// returns the open tasks around the selected one with the picker hints
+func (m *Model) buildTaskPicker() string {
+	first := max(min(m.taskCursor-taskPickerHeight/2, len(m.openTasks)-taskPickerHeight), 0)
+	last := min(first+taskPickerHeight, len(m.openTasks))
+
+	dim := lipgloss.NewStyle().Foreground(colors.Current.Dim)
+	selected := lipgloss.NewStyle().Foreground(colors.Current.Timer).Bold(true)
+
+	rows := make([]string, 0, last-first+1)
+	for i := first; i < last; i++ {
+		task := m.openTasks[i]
+
+		row := task.Title
+		if task.Pomodoros > 0 || task.Estimate > 0 {
+			row += " " + formatPomodoros(task)
+		}
+
+		if i == m.taskCursor {
+			rows = append(rows, selected.Render("› "+row))
+		} else {
+			rows = append(rows, dim.Render("  "+row))
+		}
+	}
+
+	hint := dim.Render("↑/↓ select • enter pick • esc keep")
+	rows = append(rows, hint)
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// returns the pomodoros of a task against its estimate, e.g. 🍅 2/3, or 🍅×2 without one
+func formatPomodoros(task db.Task) string {
+	if task.Estimate > 0 {
+		return fmt.Sprintf("🍅 %d/%d", task.Pomodoros, task.Estimate)
+	}
+
+	return fmt.Sprintf("🍅×%d", task.Pomodoros)
+}
+
 func (m Model) buildWaitingForCommandsView() string {
 	help := m.help.View(KeyMap{Quit: keyMap.Quit})
 
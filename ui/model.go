@@ -3,17 +3,19 @@ package ui
 import (
 	"context"
 	"log"
-	"sync"
 	"time"
 
+	"github.com/Bahaaio/pomo/actions"
 	"github.com/Bahaaio/pomo/config"
 	"github.com/Bahaaio/pomo/db"
 	"github.com/Bahaaio/pomo/ui/ascii"
 	"github.com/Bahaaio/pomo/ui/colors"
 	"github.com/Bahaaio/pomo/ui/confirm"
 	"github.com/Bahaaio/pomo/ui/summary"
+	"github.com/charmbracelet/bubbles/cursor"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/timer"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -25,23 +27,63 @@ type Model struct {
 	help          help.Model
 
 	// timer
-	timer    timer.Model
-	duration time.Duration
-	elapsed  time.Duration
+	timer     timer.Model
+	duration  time.Duration
+	elapsed   time.Duration
+	startedAt time.Time
+	step      time.Duration // of the increase and decrease keys
+	overtime  bool          // keep counting past zero until the session is finished
+
+	// inline input to set the time
+	timeInput   textinput.Model
+	editingTime bool
+	editTotal   bool // sets the total duration instead of the remaining time
+
+	// inline input to rename the session
+	titleInput   textinput.Model
+	editingTitle bool
+
+	// inline input of the note of an interruption
+	noteInput     textinput.Model
+	editingNote   bool
+	interruption  db.Interruption   // being logged
+	interruptions []db.Interruption // of the current session
+
+	// picker of the task of the work sessions
+	pickingTask bool
+	openTasks   []db.Task
+	taskCursor  int
+	task        db.Task // linked to the work sessions, zero ID for none
 
 	// state
-	width, height    int // window dimensions
+	width, height    int  // window dimensions
+	inline           bool // compact timer in place of the full screen view
 	onSessionEnd     string
 	sessionState     SessionState
 	confirmStartTime time.Time
+	remindersSent    int // while showing the confirm dialog
 	currentTaskType  config.TaskType
 	currentTask      config.Task
 	sessionSummary   summary.SessionSummary
 	isShortSession   bool
+	isLongBreak      bool
+	inOvertime       bool // counting past zero after the session ended
 	longBreak        config.LongBreak
-	cyclePosition    int             // for long break tracking
-	commandsWg       *sync.WaitGroup // post commands wg
-	commandsCancel   context.CancelFunc
+	dailyGoal        int
+	cyclePosition    int                // for long break tracking
+	postActions      context.CancelFunc // cancels the actions of the last completion
+	notifications    config.Notifications
+	reminder         config.Reminder
+	actionRunner     *actions.Runner // shared between model copies
+	commandResults   chan actions.CommandResult
+	notices          chan actions.Notice // notifications of the banner backend
+	banner           banner              // transient message shown below the timer
+
+	// flow mode, started by pomo flow
+	isFlow    bool
+	flow      config.Flow
+	stopwatch bool          // the work session counts up without an end
+	worked    time.Duration // by the last work session and its short sessions
 
 	// ASCII art
 	useTimerArt     bool
@@ -49,26 +91,18 @@ type Model struct {
 	asciiTimerStyle lipgloss.Style
 
 	// databse
-	repo *db.SessionRepo
+	repo     *db.SessionRepo
+	taskRepo *db.TaskRepo
 }
 
 func NewModel(taskType config.TaskType, cfg config.Config) Model {
 	task := taskType.GetTask()
 
-	var timerFont ascii.Font
-	timerStyle := lipgloss.NewStyle()
-
-	if cfg.ASCIIArt.Enabled {
-		timerFont = ascii.GetFont(cfg.ASCIIArt.Font)
-
-		timerColor := colors.GetColor(cfg.ASCIIArt.Color)
-		timerStyle = timerStyle.Foreground(timerColor)
-	}
-
 	sessionSummary := summary.SessionSummary{}
 
 	database, err := db.Connect()
 	var repo *db.SessionRepo
+	var taskRepo *db.TaskRepo
 
 	if err != nil {
 		// gracefully handle database connection failure
@@ -79,30 +113,153 @@ func NewModel(taskType config.TaskType, cfg config.Config) Model {
 		sessionSummary.SetDatabaseUnavailable()
 	} else {
 		repo = db.NewSessionRepo(database)
+		taskRepo = db.NewTaskRepo(database)
+	}
+
+	commandResults := make(chan actions.CommandResult, commandResultsSize)
+	notices := make(chan actions.Notice, noticesSize)
+
+	// the config is checked when it's loaded
+	registered, err := actions.FromConfig(cfg)
+	if err != nil {
+		log.Printf("failed to create actions: %v", err)
 	}
 
-	return Model{
-		progressBar:   progress.New(progress.WithDefaultGradient()),
+	m := Model{
+		progressBar:   progress.New(themeGradient()),
 		confirmDialog: confirm.New(),
 		help:          help.New(),
 
-		timer:    timer.New(task.Duration),
-		duration: task.Duration,
+		timer:      timer.New(task.Duration),
+		duration:   task.Duration,
+		startedAt:  time.Now(),
+		step:       cfg.Timer.Step,
+		overtime:   cfg.Timer.Overtime,
+		timeInput:  newTimeInput(),
+		titleInput: newTitleInput(),
+		noteInput:  newNoteInput(),
 
 		onSessionEnd:    cfg.OnSessionEnd,
+		inline:          cfg.Display == "inline",
 		sessionState:    Running,
 		currentTaskType: taskType,
 		currentTask:     *task,
 		sessionSummary:  sessionSummary,
 		longBreak:       cfg.LongBreak,
+		dailyGoal:       cfg.DailyGoal,
+		notifications:   cfg.Notifications,
+		reminder:        cfg.Reminder,
+		actionRunner:    actions.NewRunner(registered, commandResults, notices),
+		commandResults:  commandResults,
+		notices:         notices,
 		cyclePosition:   1,
 
-		useTimerArt:     cfg.ASCIIArt.Enabled,
-		timerFont:       timerFont,
-		asciiTimerStyle: timerStyle,
+		repo:     repo,
+		taskRepo: taskRepo,
+	}
+	m.setASCIIArt(cfg.ASCIIArt)
+	setStepHelp(m.step)
+	setInterruptionKeys(taskType == config.WorkTask)
+	setTaskKey(false)
 
-		repo: repo,
+	// the first work session starts right away, its task can be picked meanwhile
+	// unless it's titled after a queued task
+	if taskType == config.WorkTask && m.openTaskPicker() {
+		for _, task := range m.openTasks {
+			if task.Title == m.currentTask.Title {
+				m.task = task
+				m.closeTaskPicker()
+				break
+			}
+		}
 	}
+
+	return m
+}
+
+// NewFlowModel returns the model of flow mode: an open-ended work session,
+// followed by a break computed from its work time.
+func NewFlowModel(cfg config.Config) Model {
+	m := NewModel(config.WorkTask, cfg)
+	m.isFlow = true
+	m.flow = cfg.Flow
+
+	// breaks are already proportional to the work time
+	m.longBreak.Enabled = false
+
+	// ticks are started by Init
+	_ = m.startStopwatch()
+
+	return m
+}
+
+// returns the input of the set time key
+func newTimeInput() textinput.Model {
+	input := textinput.New()
+	input.Placeholder = "10m, 1h30m or 12:30"
+	input.CharLimit = 16
+	input.Width = 20
+	input.Cursor.SetMode(cursor.CursorStatic)
+
+	return input
+}
+
+// returns the input of the rename key, completing recent titles with tab
+func newTitleInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "title: "
+	input.CharLimit = 64
+	input.Width = 30
+	input.ShowSuggestions = true
+	input.Cursor.SetMode(cursor.CursorStatic)
+
+	return input
+}
+
+// returns the input of the optional note of an interruption
+func newNoteInput() textinput.Model {
+	input := textinput.New()
+	input.Placeholder = "optional note"
+	input.CharLimit = 80
+	input.Width = 30
+	input.Cursor.SetMode(cursor.CursorStatic)
+
+	return input
+}
+
+// sets up the ASCII art timer font and color,
+// the inline display has no room for it
+func (m *Model) setASCIIArt(art config.ASCIIArt) {
+	m.useTimerArt = art.Enabled && !m.inline
+	m.timerFont = ascii.Font{}
+	m.asciiTimerStyle = lipgloss.NewStyle()
+
+	if m.useTimerArt {
+		m.timerFont = ascii.GetFont(art.Font)
+
+		var timerColor lipgloss.TerminalColor = colors.Current.Timer
+		if art.Color != "" {
+			timerColor = colors.GetColor(art.Color)
+		}
+
+		m.asciiTimerStyle = m.asciiTimerStyle.Foreground(timerColor)
+	}
+}
+
+// returns the progress bar gradient of the current theme
+func themeGradient() progress.Option {
+	return progress.WithGradient(
+		string(colors.Current.ProgressStart),
+		string(colors.Current.ProgressEnd),
+	)
+}
+
+type banner struct {
+	id        int // incremented for each banner, so only the latest one gets cleared
+	text      string
+	isError   bool
+	flashing  bool // notification banners alternate between normal and reversed colors
+	highlight bool
 }
 
 type SessionState byte
@@ -11,8 +11,6 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-var barStyle = lipgloss.NewStyle().Foreground(colors.WorkSessionFg)
-
 const (
 	barChar     = "█"
 	axisChar    = "│"
@@ -184,7 +182,7 @@ func renderBar(height int) string {
 	bar := strings.Repeat(barChar, barThickness)
 
 	// don't render last new line char
-	return barStyle.Render(strings.Repeat(bar+"\n", height-1) + bar)
+	return lipgloss.NewStyle().Foreground(colors.Current.WorkSession).Render(strings.Repeat(bar+"\n", height-1) + bar)
 }
 
 func getMaxDuration(stats []db.DailyStat) time.Duration {
@@ -10,11 +10,6 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-var (
-	workBarStyle  = lipgloss.NewStyle().Foreground(colors.WorkSessionFg)
-	breakBarStyle = lipgloss.NewStyle().Foreground(colors.BreakSessionFg)
-)
-
 type DurationRatio struct {
 	width int
 }
@@ -63,8 +58,8 @@ func (d *DurationRatio) buildBar(workPercentage int) string {
 	filledWidth := int(float64(d.width) * (float64(workPercentage) / 100.0))
 	emptyWidth := d.width - filledWidth
 
-	workPart := workBarStyle.Render(strings.Repeat("█", filledWidth))
-	breakPart := breakBarStyle.Render(strings.Repeat("░", emptyWidth))
+	workPart := lipgloss.NewStyle().Foreground(colors.Current.WorkSession).Render(strings.Repeat("█", filledWidth))
+	breakPart := lipgloss.NewStyle().Foreground(colors.Current.BreakSession).Render(strings.Repeat("░", emptyWidth))
 
 	return workPart + breakPart
 }
@@ -0,0 +1,105 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Bahaaio/pomo/db"
+	"github.com/Bahaaio/pomo/ui/colors"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	estimatedChar = "█"
+	overChar      = "▒"
+	underChar     = "░"
+
+	estimateTitleWidth = 14
+)
+
+// Estimates compares the completed pomodoros of the latest done tasks to their estimates,
+// along with the average accuracy of the estimates.
+type Estimates struct {
+	width int // of the longest bar
+}
+
+func NewEstimates(width int) Estimates {
+	return Estimates{
+		width: width,
+	}
+}
+
+// View returns the comparison, or an empty string if no tasks were estimated.
+func (e *Estimates) View(tasks []db.Task) string {
+	if len(tasks) == 0 {
+		return ""
+	}
+
+	maxPomodoros := 0
+	accuracy := 0.0
+	for _, task := range tasks {
+		maxPomodoros = max(maxPomodoros, task.Pomodoros, task.Estimate)
+		accuracy += task.Accuracy()
+	}
+	accuracy /= float64(len(tasks))
+
+	rows := make([]string, 0, len(tasks)+4)
+	rows = append(rows, fmt.Sprintf("estimates · %.0f%% accurate", accuracy*100), "")
+
+	for _, task := range tasks {
+		rows = append(rows, e.buildRow(task, maxPomodoros))
+	}
+
+	rows = append(rows, "", e.buildLegend())
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// returns the title, the bar and the pomodoros against the estimate of a task
+func (e *Estimates) buildRow(task db.Task, maxPomodoros int) string {
+	// one cell per pomodoro, unless they don't fit
+	scale := min(float64(e.width)/float64(maxPomodoros), 1)
+	cells := func(pomodoros int) int {
+		if pomodoros == 0 {
+			return 0
+		}
+		return max(int(float64(pomodoros)*scale), 1)
+	}
+
+	estimated := cells(min(task.Pomodoros, task.Estimate))
+	over := cells(max(task.Pomodoros-task.Estimate, 0))
+	under := cells(max(task.Estimate-task.Pomodoros, 0))
+
+	bar := estimatedStyle().Render(strings.Repeat(estimatedChar, estimated)) +
+		overStyle().Render(strings.Repeat(overChar, over)) +
+		underStyle().Render(strings.Repeat(underChar, under))
+	padding := strings.Repeat(paddingChar, max(e.width-estimated-over-under, 0))
+
+	count := fmt.Sprintf("%d/%d", task.Pomodoros, task.Estimate)
+
+	return truncateTitle(task.Title) + paddingChar + bar + padding + paddingChar + count
+}
+
+func (e *Estimates) buildLegend() string {
+	return estimatedStyle().Render(estimatedChar) + " estimated  " +
+		overStyle().Render(overChar) + " over  " +
+		underStyle().Render(underChar) + " under"
+}
+
+// truncates or pads the title to the title column
+func truncateTitle(title string) string {
+	title = lipgloss.NewStyle().MaxWidth(estimateTitleWidth).Render(title)
+	return title + strings.Repeat(paddingChar, estimateTitleWidth-lipgloss.Width(title))
+}
+
+func estimatedStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(colors.Current.WorkSession)
+}
+
+func overStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(colors.Current.ErrorMessage)
+}
+
+func underStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(colors.Current.Dim)
+}
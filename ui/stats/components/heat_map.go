@@ -22,13 +22,6 @@ const (
 )
 
 var (
-	style0 = lipgloss.NewStyle().Foreground(colors.HeatMapFg0)
-	style1 = lipgloss.NewStyle().Foreground(colors.HeatMapFg1)
-	style2 = lipgloss.NewStyle().Foreground(colors.HeatMapFg2)
-	style3 = lipgloss.NewStyle().Foreground(colors.HeatMapFg3)
-	style4 = lipgloss.NewStyle().Foreground(colors.HeatMapFg4)
-	styles = []lipgloss.Style{style0, style1, style2, style3, style4}
-
 	paddingStyle   = lipgloss.NewStyle().Padding(1)
 	leftAlignStyle = lipgloss.NewStyle().Align(lipgloss.Left)
 )
@@ -69,8 +62,8 @@ func (h *HeatMap) buildLegend() string {
 	builder := strings.Builder{}
 	builder.WriteString("Less ")
 
-	for _, style := range styles {
-		builder.WriteString(style.Render(cellChar))
+	for _, color := range heatMapColors() {
+		builder.WriteString(lipgloss.NewStyle().Foreground(color).Render(cellChar))
 	}
 
 	builder.WriteString(" More")
@@ -224,15 +217,25 @@ func renderCell(duration time.Duration) string {
 }
 
 func getCellStyle(duration time.Duration) lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(heatMapColors()[getCellLevel(duration)])
+}
+
+func getCellLevel(duration time.Duration) int {
 	if duration < time.Second {
-		return style0
+		return 0
 	} else if duration <= time.Minute*30 {
-		return style1
+		return 1
 	} else if duration <= time.Hour {
-		return style2
+		return 2
 	} else if duration <= time.Hour*2 {
-		return style3
+		return 3
 	} else {
-		return style4
+		return 4
 	}
 }
+
+// returns the heat map colors of the current theme, from no activity to the most
+func heatMapColors() []lipgloss.Color {
+	theme := colors.Current
+	return []lipgloss.Color{theme.HeatMap0, theme.HeatMap1, theme.HeatMap2, theme.HeatMap3, theme.HeatMap4}
+}
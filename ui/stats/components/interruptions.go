@@ -0,0 +1,87 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Bahaaio/pomo/db"
+	"github.com/Bahaaio/pomo/ui/colors"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	internalChar = "█"
+	externalChar = "▒"
+)
+
+// Interruptions charts the internal and external interruptions per day.
+type Interruptions struct {
+	width int // of the longest bar
+}
+
+func NewInterruptions(width int) Interruptions {
+	return Interruptions{
+		width: width,
+	}
+}
+
+// View returns the chart, or an empty string if there are no interruptions.
+func (i *Interruptions) View(stats []db.DailyInterruptions) string {
+	maxTotal := 0
+	for _, stat := range stats {
+		maxTotal = max(maxTotal, stat.Total())
+	}
+
+	if maxTotal == 0 {
+		return ""
+	}
+
+	rows := make([]string, 0, len(stats)+2)
+	rows = append(rows, "interruptions", "")
+
+	for _, stat := range stats {
+		rows = append(rows, i.buildRow(stat, maxTotal))
+	}
+
+	rows = append(rows, "", i.buildLegend())
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// returns the day label, the bar and the count of a day
+func (i *Interruptions) buildRow(stat db.DailyInterruptions, maxTotal int) string {
+	internalWidth := stat.Internal * i.width / maxTotal
+	externalWidth := stat.External * i.width / maxTotal
+
+	// keep a single interruption visible
+	if stat.Internal > 0 {
+		internalWidth = max(internalWidth, 1)
+	}
+	if stat.External > 0 {
+		externalWidth = max(externalWidth, 1)
+	}
+
+	bar := internalStyle().Render(strings.Repeat(internalChar, internalWidth)) +
+		externalStyle().Render(strings.Repeat(externalChar, externalWidth))
+	padding := strings.Repeat(paddingChar, max(i.width-internalWidth-externalWidth, 0))
+
+	count := ""
+	if stat.Total() > 0 {
+		count = fmt.Sprint(stat.Total())
+	}
+
+	return getDayLabel(stat.Date) + paddingChar + bar + padding + paddingChar + count
+}
+
+func (i *Interruptions) buildLegend() string {
+	return internalStyle().Render(internalChar) + " internal  " +
+		externalStyle().Render(externalChar) + " external"
+}
+
+func internalStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(colors.Current.WorkSession)
+}
+
+func externalStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(colors.Current.ErrorMessage)
+}
@@ -1,6 +1,9 @@
 package stats
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"github.com/Bahaaio/pomo/ui/keymap"
+	"github.com/charmbracelet/bubbles/key"
+)
 
 type KeyMap struct {
 	Quit key.Binding
@@ -16,9 +19,24 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{}
 }
 
-var Keys = KeyMap{
+// Keys are the active key bindings, see [DefaultKeys]
+var Keys = DefaultKeys
+
+var DefaultKeys = KeyMap{
 	Quit: key.NewBinding(
 		key.WithKeys("ctrl+c", "q"),
 		key.WithHelp("q", "quit"),
 	),
 }
+
+// ApplyKeys remaps the stats key bindings,
+// keeping the current ones if any of them is invalid.
+func ApplyKeys(remap map[string][]string) error {
+	keys := DefaultKeys
+	if err := keymap.Remap("stats", &keys, remap); err != nil {
+		return err
+	}
+
+	Keys = keys
+	return nil
+}
@@ -16,10 +16,14 @@ import (
 const (
 	barChartHeight     = 12
 	durationRatioWidth = 30
+	interruptionsWidth = 20
+	estimatesWidth     = 12
+
+	// latest done tasks compared to their estimates
+	estimatedTasksLimit = 7
 )
 
 var errStyle = lipgloss.NewStyle().
-	Foreground(colors.ErrorMessageFg).
 	AlignHorizontal(lipgloss.Center)
 
 type Model struct {
@@ -28,6 +32,8 @@ type Model struct {
 	barChart      components.BarChart
 	heatMap       components.HeatMap
 	streak        components.Streak
+	interruptions components.Interruptions
+	estimates     components.Estimates
 
 	// error message
 	err error
@@ -38,6 +44,9 @@ type Model struct {
 	monthlyStats []db.DailyStat
 	streakStats  db.StreakStats
 
+	interruptionStats []db.DailyInterruptions
+	estimatedTasks    []db.Task
+
 	// state
 	width, height int
 	help          help.Model
@@ -50,6 +59,8 @@ func New() Model {
 		barChart:      components.NewBarChart(barChartHeight),
 		heatMap:       components.NewHeatMap(),
 		streak:        components.NewStreak(),
+		interruptions: components.NewInterruptions(interruptionsWidth),
+		estimates:     components.NewEstimates(estimatesWidth),
 		help:          help.New(),
 	}
 }
@@ -59,6 +70,9 @@ type statsMsg struct {
 	weeklyStats  []db.DailyStat
 	monthlyStats []db.DailyStat
 	streakStats  db.StreakStats
+
+	interruptionStats []db.DailyInterruptions
+	estimatedTasks    []db.Task
 }
 
 type errMsg struct {
@@ -95,11 +109,23 @@ func fetchStats() tea.Msg {
 		return errMsg{err: errors.New("failed to fetch streak stats")}
 	}
 
+	interruptionStats, err := repo.GetWeeklyInterruptions()
+	if err != nil {
+		return errMsg{err: errors.New("failed to fetch interruption stats")}
+	}
+
+	estimatedTasks, err := db.NewTaskRepo(database).GetEstimatedTasks(estimatedTasksLimit)
+	if err != nil {
+		return errMsg{err: errors.New("failed to fetch estimated tasks")}
+	}
+
 	return statsMsg{
-		allTimeStats: stats,
-		weeklyStats:  weeklyStats,
-		monthlyStats: monthlyStats,
-		streakStats:  streakStats,
+		allTimeStats:      stats,
+		weeklyStats:       weeklyStats,
+		monthlyStats:      monthlyStats,
+		streakStats:       streakStats,
+		interruptionStats: interruptionStats,
+		estimatedTasks:    estimatedTasks,
 	}
 }
 
@@ -130,6 +156,18 @@ func (m Model) View() string {
 
 	charts := lipgloss.JoinHorizontal(lipgloss.Bottom, chart, "   ", hMap)
 
+	// only shown once interruptions are logged or tasks estimated,
+	// next to the charts if they fit, otherwise below them
+	if panels := m.buildPanels(); panels != "" {
+		beside := lipgloss.JoinHorizontal(lipgloss.Bottom, charts, "   ", panels)
+
+		if lipgloss.Width(beside) <= m.width {
+			charts = beside
+		} else {
+			charts = lipgloss.JoinVertical(lipgloss.Center, charts, "", panels)
+		}
+	}
+
 	return lipgloss.Place(
 		m.width, m.height,
 		lipgloss.Center, lipgloss.Center,
@@ -155,6 +193,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.weeklyStats = msg.weeklyStats
 		m.monthlyStats = msg.monthlyStats
 		m.streakStats = msg.streakStats
+		m.interruptionStats = msg.interruptionStats
+		m.estimatedTasks = msg.estimatedTasks
 		return m, nil
 	case errMsg:
 		m.err = msg.err
@@ -170,6 +210,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 }
 
+// returns the interruptions and estimates panels side by side, leaving out the empty ones
+func (m *Model) buildPanels() string {
+	var panels []string
+
+	for _, panel := range []string{
+		m.interruptions.View(m.interruptionStats),
+		m.estimates.View(m.estimatedTasks),
+	} {
+		if panel == "" {
+			continue
+		}
+
+		if len(panels) > 0 {
+			panels = append(panels, "   ")
+		}
+		panels = append(panels, panel)
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Bottom, panels...)
+}
+
 func (m *Model) buildErrorMessage() string {
 	title := "An error occurred while fetching statistics."
 	message := m.err.Error()
@@ -188,7 +249,7 @@ func (m *Model) buildErrorMessage() string {
 	return lipgloss.Place(
 		m.width, m.height,
 		lipgloss.Center, lipgloss.Center,
-		errStyle.Render(content),
+		errStyle.Foreground(colors.Current.ErrorMessage).Render(content),
 	)
 }
 
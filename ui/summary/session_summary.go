@@ -6,23 +6,26 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Bahaaio/pomo/actions"
 	"github.com/Bahaaio/pomo/config"
 	"github.com/Bahaaio/pomo/ui/colors"
 	"github.com/charmbracelet/lipgloss"
 )
 
-var (
-	messageStyle = lipgloss.NewStyle().Foreground(colors.SuccessMessageFg)
-	errorStyle   = lipgloss.NewStyle().Foreground(colors.ErrorMessageFg)
-)
-
 type SessionSummary struct {
-	totalWorkSessions int
-	totalWorkDuration time.Duration
+	totalWorkSessions     int
+	completedWorkSessions int // that ran for their planned duration
+	totalWorkDuration     time.Duration
 
 	totalBreakSessions int
 	totalBreakDuration time.Duration
 
+	overtime time.Duration // part of the work and break durations
+
+	tasks []taskProgress // worked on, in the order they were picked
+
+	failedCommands []string
+
 	isDatabaseUnavailable bool
 }
 
@@ -46,6 +49,46 @@ func (t *SessionSummary) AddDuration(taskType config.TaskType, duration time.Dur
 	}
 }
 
+// AddOvertime adds the time a session ran past zero, it's already part of the session duration.
+func (t *SessionSummary) AddOvertime(overtime time.Duration) {
+	t.overtime += overtime
+}
+
+// WorkDuration returns the total work duration of the summarized sessions.
+func (t SessionSummary) WorkDuration() time.Duration {
+	return t.totalWorkDuration
+}
+
+// CompleteWorkSession counts a work session that ran for its planned duration,
+// it's already added as a session.
+func (t *SessionSummary) CompleteWorkSession() {
+	t.completedWorkSessions++
+}
+
+// CompletedWorkSessions returns the number of summarized work sessions that were completed.
+func (t SessionSummary) CompletedWorkSessions() int {
+	return t.completedWorkSessions
+}
+
+// SetTask sets the pomodoros of a task worked on, and its estimate or zero if it's not estimated.
+func (t *SessionSummary) SetTask(id int, title string, pomodoros, estimate int) {
+	progress := taskProgress{id: id, title: title, pomodoros: pomodoros, estimate: estimate}
+
+	for i, task := range t.tasks {
+		if task.id == id {
+			t.tasks[i] = progress
+			return
+		}
+	}
+
+	t.tasks = append(t.tasks, progress)
+}
+
+// AddFailedCommand adds a failed post command or hook to the summary.
+func (t *SessionSummary) AddFailedCommand(source, summary string) {
+	t.failedCommands = append(t.failedCommands, fmt.Sprintf("[%v] %v", source, summary))
+}
+
 // SetDatabaseUnavailable marks the database as unavailable.
 // prints a warning in the summary.
 func (t *SessionSummary) SetDatabaseUnavailable() {
@@ -54,6 +97,8 @@ func (t *SessionSummary) SetDatabaseUnavailable() {
 
 // Print prints the session summary to the console.
 func (t SessionSummary) Print() {
+	t.printFailedCommands()
+
 	if t.totalWorkDuration == 0 && t.totalBreakDuration == 0 {
 		return
 	}
@@ -68,7 +113,7 @@ func (t SessionSummary) Print() {
 		breakIndicator = "session"
 	}
 
-	fmt.Println(messageStyle.Render("Session Summary:"))
+	fmt.Println(lipgloss.NewStyle().Foreground(colors.Current.SuccessMessage).Render("Session Summary:"))
 
 	if t.totalWorkDuration > 0 {
 		fmt.Printf(" Work : %v (%d %s)\n", t.totalWorkDuration, t.totalWorkSessions, workIndicator)
@@ -82,13 +127,75 @@ func (t SessionSummary) Print() {
 		fmt.Println(" Total:", t.totalWorkDuration+t.totalBreakDuration)
 	}
 
+	if t.overtime > 0 {
+		fmt.Println(" Extra:", t.overtime, "overtime")
+	}
+
+	for _, task := range t.tasks {
+		fmt.Printf(" Task : %v (%v)\n", task.title, task.progress())
+	}
+
 	if t.totalWorkDuration > 0 {
 		t.printProgressBar()
 	}
 
 	if t.isDatabaseUnavailable {
-		fmt.Println(errorStyle.Render("\n Not saved (database unavailable)"))
+		fmt.Println(lipgloss.NewStyle().Foreground(colors.Current.ErrorMessage).Render("\n Not saved (database unavailable)"))
+	}
+}
+
+type taskProgress struct {
+	id        int
+	title     string
+	pomodoros int // of the task so far, not only of this run
+	estimate  int
+}
+
+// returns the pomodoros against the estimate, e.g. 4/3 pomodoros, 1 over the estimate
+func (p taskProgress) progress() string {
+	if p.estimate == 0 {
+		indicator := "pomodoros"
+		if p.pomodoros == 1 {
+			indicator = "pomodoro"
+		}
+
+		return fmt.Sprintf("%d %s", p.pomodoros, indicator)
+	}
+
+	indicator := "pomodoros"
+	if p.estimate == 1 {
+		indicator = "pomodoro"
+	}
+
+	progress := fmt.Sprintf("%d/%d %s", p.pomodoros, p.estimate, indicator)
+
+	switch {
+	case p.pomodoros > p.estimate:
+		progress += fmt.Sprintf(", %d over the estimate", p.pomodoros-p.estimate)
+	case p.pomodoros == p.estimate:
+		progress += ", on the estimate"
+	}
+
+	return progress
+}
+
+// lists the commands that failed during the session
+func (t SessionSummary) printFailedCommands() {
+	if len(t.failedCommands) == 0 {
+		return
+	}
+
+	errorStyle := lipgloss.NewStyle().Foreground(colors.Current.ErrorMessage)
+	fmt.Println(errorStyle.Render("Failed Commands:"))
+
+	for _, command := range t.failedCommands {
+		fmt.Println(" " + command)
+	}
+
+	if path, err := actions.CommandLogPath(); err == nil {
+		fmt.Println(" See", path, "for details")
 	}
+	fmt.Println()
 }
 
 // prints a progress bar showing the ratio of work to total time.
@@ -101,7 +208,7 @@ func (t SessionSummary) printProgressBar() {
 	filledWidth := int(workRatio * barWidth)
 	emptyWidth := barWidth - filledWidth
 
-	bar := lipgloss.NewStyle().Foreground(colors.TimerFg).
+	bar := lipgloss.NewStyle().Foreground(colors.Current.Timer).
 		Render(strings.Repeat("█", filledWidth)) +
 		strings.Repeat("░", emptyWidth)
 
@@ -0,0 +1,164 @@
+// Package xdg resolves base directories following the XDG base directory specification.
+//
+// XDG variables are only honored on Linux and macOS,
+// other OSes use the standard user config directory.
+package xdg
+
+import (
+	"errors"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// ConfigHome returns $XDG_CONFIG_HOME, defaulting to ~/.config
+func ConfigHome() (string, error) {
+	return resolve("XDG_CONFIG_HOME", ".config")
+}
+
+// StateHome returns $XDG_STATE_HOME, defaulting to ~/.local/state
+func StateHome() (string, error) {
+	return resolve("XDG_STATE_HOME", filepath.Join(".local", "state"))
+}
+
+// DataHome returns $XDG_DATA_HOME, defaulting to ~/.local/share
+func DataHome() (string, error) {
+	return resolve("XDG_DATA_HOME", filepath.Join(".local", "share"))
+}
+
+// DefaultConfigHome returns ~/.config, ignoring $XDG_CONFIG_HOME.
+func DefaultConfigHome() (string, error) {
+	return resolve("", ".config")
+}
+
+// DefaultStateHome returns ~/.local/state, ignoring $XDG_STATE_HOME.
+func DefaultStateHome() (string, error) {
+	return resolve("", filepath.Join(".local", "state"))
+}
+
+// ConfigDirs returns the system-wide config directories, most important first.
+// uses $XDG_CONFIG_DIRS, defaulting to /etc/xdg
+func ConfigDirs() []string {
+	return resolveDirs("XDG_CONFIG_DIRS", "/etc/xdg")
+}
+
+// DataDirs returns the system-wide data directories, most important first.
+// uses $XDG_DATA_DIRS, defaulting to /usr/local/share and /usr/share
+func DataDirs() []string {
+	return resolveDirs("XDG_DATA_DIRS", "/usr/local/share", "/usr/share")
+}
+
+// Migrate moves a file to its new location once,
+// if it exists at the old location and not at the new one.
+//
+// returns whether the file was moved.
+func Migrate(from, to string) (bool, error) {
+	if from == to {
+		return false, nil
+	}
+
+	if _, err := os.Stat(to); err == nil {
+		return false, nil
+	}
+
+	if _, err := os.Stat(from); err != nil {
+		return false, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(to), 0o755); err != nil {
+		return false, err
+	}
+
+	// rename fails across file systems, fall back to copying
+	if err := os.Rename(from, to); err != nil {
+		if err = copyFile(from, to); err != nil {
+			return false, err
+		}
+
+		if err = os.Remove(from); err != nil {
+			return false, err
+		}
+	}
+
+	log.Printf("migrated %v to %v", from, to)
+	return true, nil
+}
+
+// returns the directory from env if set to an absolute path,
+// or the fallback relative to the home directory
+func resolve(env, fallback string) (string, error) {
+	// on other OSes, use the standard user config directory
+	if !isUnix() {
+		return os.UserConfigDir()
+	}
+
+	if dir := os.Getenv(env); env != "" && filepath.IsAbs(dir) {
+		return dir, nil
+	}
+
+	home := os.Getenv("HOME")
+	if home == "" {
+		return "", errors.New("$HOME is not defined")
+	}
+
+	return filepath.Join(home, fallback), nil
+}
+
+// returns the absolute directories of a colon separated env list,
+// or the fallback ones if there are none
+func resolveDirs(env string, fallback ...string) []string {
+	// on other OSes, use the system-wide ProgramData directory
+	if !isUnix() {
+		if dir := os.Getenv("ProgramData"); dir != "" {
+			return []string{dir}
+		}
+		return nil
+	}
+
+	var dirs []string
+	for dir := range strings.SplitSeq(os.Getenv(env), ":") {
+		// relative paths are invalid per the spec
+		if filepath.IsAbs(dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	if len(dirs) == 0 {
+		dirs = fallback
+	}
+
+	return dirs
+}
+
+func isUnix() bool {
+	return runtime.GOOS == "linux" || runtime.GOOS == "darwin"
+}
+
+func copyFile(from, to string) error {
+	src, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	dst, err := os.OpenFile(to, os.O_CREATE|os.O_EXCL|os.O_WRONLY, info.Mode())
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(to)
+		return err
+	}
+
+	return dst.Close()
+}
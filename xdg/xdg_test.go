@@ -0,0 +1,118 @@
+package xdg
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func TestResolve(t *testing.T) {
+	if !isUnix() {
+		t.Skip("XDG variables are only honored on Linux and macOS")
+	}
+
+	t.Setenv("HOME", "/home/user")
+
+	testCases := []struct {
+		name       string
+		configHome string
+		stateHome  string
+		dataHome   string
+		wantConfig string
+		wantState  string
+		wantData   string
+	}{
+		{"defaults", "", "", "", "/home/user/.config", "/home/user/.local/state", "/home/user/.local/share"},
+		{"absolute", "/xdg/config", "/xdg/state", "/xdg/data", "/xdg/config", "/xdg/state", "/xdg/data"},
+		{"relative paths are ignored", "config", "state", "data", "/home/user/.config", "/home/user/.local/state", "/home/user/.local/share"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", tt.configHome)
+			t.Setenv("XDG_STATE_HOME", tt.stateHome)
+			t.Setenv("XDG_DATA_HOME", tt.dataHome)
+
+			configHome, err := ConfigHome()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantConfig, configHome)
+
+			stateHome, err := StateHome()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantState, stateHome)
+
+			dataHome, err := DataHome()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantData, dataHome)
+
+			// defaults never change
+			defaultConfigHome, _ := DefaultConfigHome()
+			assert.Equal(t, "/home/user/.config", defaultConfigHome)
+		})
+	}
+}
+
+func TestConfigDirs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("XDG variables are only honored on Linux and macOS")
+	}
+
+	t.Setenv("XDG_CONFIG_DIRS", "")
+	assert.Equal(t, []string{"/etc/xdg"}, ConfigDirs())
+
+	t.Setenv("XDG_CONFIG_DIRS", "/opt/xdg:relative:/etc/xdg")
+	assert.Equal(t, []string{"/opt/xdg", "/etc/xdg"}, ConfigDirs())
+}
+
+func TestDataDirs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("XDG variables are only honored on Linux and macOS")
+	}
+
+	t.Setenv("XDG_DATA_DIRS", "")
+	assert.Equal(t, []string{"/usr/local/share", "/usr/share"}, DataDirs())
+
+	t.Setenv("XDG_DATA_DIRS", "/opt/share:share:/usr/share")
+	assert.Equal(t, []string{"/opt/share", "/usr/share"}, DataDirs())
+}
+
+func TestMigrate(t *testing.T) {
+	dir := t.TempDir()
+	from := filepath.Join(dir, "old", "pomo.yaml")
+	to := filepath.Join(dir, "new", "pomo", "pomo.yaml")
+
+	// nothing to migrate
+	moved, err := Migrate(from, to)
+	assert.NoError(t, err)
+	assert.False(t, moved)
+
+	assert.NoError(t, os.MkdirAll(filepath.Dir(from), 0o755))
+	assert.NoError(t, os.WriteFile(from, []byte("work: {}"), 0o644))
+
+	moved, err = Migrate(from, to)
+	assert.NoError(t, err)
+	assert.True(t, moved)
+
+	data, err := os.ReadFile(to)
+	assert.NoError(t, err)
+	assert.Equal(t, "work: {}", string(data))
+	assert.NoFileExists(t, from)
+
+	// an existing file is never overwritten
+	assert.NoError(t, os.WriteFile(from, []byte("old"), 0o644))
+
+	moved, err = Migrate(from, to)
+	assert.NoError(t, err)
+	assert.False(t, moved)
+	assert.FileExists(t, from)
+}